@@ -0,0 +1,267 @@
+// simulate 在命令行中运行负载文件，不需要启动HTTP服务。
+//
+// 用法：
+//
+//	go run ./cmd/simulate -workload workload.json -policy rr -quantum 2 -allocator best_fit
+//	go run ./cmd/simulate -workload workload.json -compare priority,fcfs,sjf,rr -compare-allocators first_fit,best_fit
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os-scheduler-backend/models"
+	"os-scheduler-backend/services"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// ganttWidth 甘特图每行显示的时钟周期数
+const ganttWidth = 40
+
+func main() {
+	workloadFile := flag.String("workload", "", "负载文件路径（必填）")
+	configFlags := services.BindConfigFlags(flag.CommandLine)
+	maxTicks := flag.Int("max-ticks", 0, fmt.Sprintf("最大模拟时钟周期数，默认%d，对比时默认且最多%d", services.DefaultMaxTicks, services.MaxCompareTicks))
+	compare := flag.String("compare", "", "逗号分隔的调度策略列表，指定后对比各策略在同一负载上的表现")
+	compareAllocators := flag.String("compare-allocators", "", "逗号分隔的内存分配算法列表，与-compare一起使用")
+	format := flag.String("format", "text", "输出格式：text、json、csv")
+	output := flag.String("o", "", "输出文件路径，默认输出到标准输出")
+	flag.Parse()
+
+	if *workloadFile == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	cfg, err := configFlags.Load()
+	if err != nil {
+		log.Fatalf("读取系统参数失败: %v", err)
+	}
+
+	workload, err := services.LoadWorkload(*workloadFile)
+	if err != nil {
+		log.Fatalf("读取负载失败: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("创建输出文件失败: %v", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if *compare != "" || *compareAllocators != "" {
+		var policies []models.SchedulingPolicy
+		for _, name := range splitList(*compare) {
+			policies = append(policies, models.SchedulingPolicy(name))
+		}
+		var allocators []models.AllocationAlgorithm
+		for _, name := range splitList(*compareAllocators) {
+			allocators = append(allocators, models.AllocationAlgorithm(name))
+		}
+
+		if *maxTicks == 0 {
+			*maxTicks = services.MaxCompareTicks
+		}
+		rows, err := services.ComparePolicies(workload, cfg, policies, allocators, *maxTicks)
+		if err != nil {
+			log.Fatalf("对比失败: %v", err)
+		}
+		if err := writeComparison(w, *format, rows); err != nil {
+			log.Fatalf("输出结果失败: %v", err)
+		}
+		return
+	}
+
+	if *maxTicks == 0 {
+		*maxTicks = services.DefaultMaxTicks
+	}
+	result, err := services.RunWorkload(workload, cfg, *maxTicks)
+	if err != nil {
+		log.Fatalf("模拟失败: %v", err)
+	}
+
+	switch *format {
+	case "text":
+		err = writeText(w, result)
+	case "json":
+		err = writeJSON(w, result)
+	case "csv":
+		err = writeCSV(w, result)
+	default:
+		log.Fatalf("不支持的输出格式 %s", *format)
+	}
+	if err != nil {
+		log.Fatalf("输出结果失败: %v", err)
+	}
+}
+
+// splitList 拆分逗号分隔的列表，忽略空项
+func splitList(list string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func writeComparison(w io.Writer, format string, rows []models.ComparisonRow) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"policy", "allocator", "makespan", "avg_waiting", "avg_turnaround", "avg_response", "throughput", "cpu_utilization", "context_switches", "effective_utilization", "error"})
+		for _, row := range rows {
+			writer.Write([]string{
+				string(row.Policy),
+				string(row.Allocator),
+				strconv.Itoa(row.Makespan),
+				strconv.FormatFloat(row.AvgWaiting, 'f', 4, 64),
+				strconv.FormatFloat(row.AvgTurnaround, 'f', 4, 64),
+				strconv.FormatFloat(row.AvgResponse, 'f', 4, 64),
+				strconv.FormatFloat(row.Throughput, 'f', 4, 64),
+				strconv.FormatFloat(row.CPUUtilization, 'f', 4, 64),
+				strconv.Itoa(row.ContextSwitches),
+				strconv.FormatFloat(row.EffectiveUtilization, 'f', 4, 64),
+				row.Error,
+			})
+		}
+		writer.Flush()
+		return writer.Error()
+	case "text":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "策略\t分配算法\t总时长\t平均等待\t平均周转\t平均响应\t吞吐量\t利用率\t切换次数\t有效利用率\t")
+		for _, row := range rows {
+			if row.Error != "" {
+				fmt.Fprintf(tw, "%s\t%s\t模拟失败: %s\n", row.Policy, row.Allocator, row.Error)
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%.2f\t%.2f\t%.2f\t%.4f\t%.2f%%\t%d\t%.2f%%\t\n",
+				row.Policy, row.Allocator, row.Makespan, row.AvgWaiting, row.AvgTurnaround,
+				row.AvgResponse, row.Throughput, row.CPUUtilization*100, row.ContextSwitches, row.EffectiveUtilization*100)
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("不支持的输出格式 %s", format)
+	}
+}
+
+func writeJSON(w io.Writer, result *models.SimulationResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+func writeCSV(w io.Writer, result *models.SimulationResult) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"pid", "name", "arrival", "start", "finish", "burst", "turnaround", "waiting", "response"})
+	for _, p := range result.Stats.PerProcess {
+		writer.Write([]string{
+			strconv.Itoa(p.PID),
+			p.Name,
+			strconv.Itoa(p.Arrival),
+			strconv.Itoa(p.Start),
+			strconv.Itoa(p.Finish),
+			strconv.Itoa(p.Burst),
+			strconv.Itoa(p.Turnaround),
+			strconv.Itoa(p.Waiting),
+			strconv.Itoa(p.Response),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeText(w io.Writer, result *models.SimulationResult) error {
+	stats := result.Stats
+	fmt.Fprintf(w, "负载: %s\n", result.Workload)
+	fmt.Fprintf(w, "调度策略: %s  内存分配算法: %s  处理机: %d  道数: %d\n\n",
+		result.Config.Policy, result.Config.Allocator, result.Config.ProcessorCount, result.Config.MaxProcesses)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "PID\t名称\t到达\t开始\t完成\t运行\t周转\t等待\t响应\t")
+	for _, p := range stats.PerProcess {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t\n",
+			p.PID, p.Name, p.Arrival, p.Start, p.Finish, p.Burst, p.Turnaround, p.Waiting, p.Response)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\n总时长: %d  完成进程: %d/%d\n", stats.Clock, stats.Finished, stats.Processes)
+	fmt.Fprintf(w, "平均周转时间: %.2f  平均等待时间: %.2f  平均响应时间: %.2f\n",
+		stats.AvgTurnaround, stats.AvgWaiting, stats.AvgResponse)
+	fmt.Fprintf(w, "吞吐量: %.4f  处理机利用率: %.2f%%\n", stats.Throughput, stats.CPUUtilization*100)
+	fmt.Fprintf(w, "上下文切换: %d  切换开销: %d  有效利用率: %.2f%%\n\n",
+		stats.ContextSwitches, stats.OverheadTicks, stats.EffectiveUtilization*100)
+	if len(stats.Groups) > 1 {
+		for _, g := range stats.Groups {
+			fmt.Fprintf(w, "用户组 %s: 份额 %d  处理机时间 %d  占比: %.2f%%  期望: %.2f%%\n",
+				g.Name, g.Shares, g.CPUTime, g.Usage*100, g.ExpectedShare*100)
+		}
+		fmt.Fprintln(w)
+	}
+
+	_, err := io.WriteString(w, renderGantt(result.Timeline))
+	return err
+}
+
+// renderGantt 将时间线渲染为文本甘特图，每行显示ganttWidth个时钟周期，'.'表示处理机空闲
+func renderGantt(timeline []models.TimelineSlot) string {
+	processors, cell := 0, 1
+	for _, slot := range timeline {
+		if len(slot.Processors) > processors {
+			processors = len(slot.Processors)
+		}
+		for _, pid := range slot.Processors {
+			if width := len(strconv.Itoa(pid)); pid >= 0 && width > cell {
+				cell = width
+			}
+		}
+	}
+
+	var b strings.Builder
+	for from := 0; from < len(timeline); from += ganttWidth {
+		to := from + ganttWidth
+		if to > len(timeline) {
+			to = len(timeline)
+		}
+
+		fmt.Fprintf(&b, "%-6s", "tick")
+		for _, slot := range timeline[from:to] {
+			if slot.Tick%5 == 0 {
+				fmt.Fprintf(&b, "%-*d", cell+1, slot.Tick)
+			} else {
+				fmt.Fprintf(&b, "%-*s", cell+1, "")
+			}
+		}
+		b.WriteString("\n")
+
+		for cpu := 0; cpu < processors; cpu++ {
+			fmt.Fprintf(&b, "%-6s", fmt.Sprintf("CPU%d", cpu))
+			for _, slot := range timeline[from:to] {
+				label := "."
+				if cpu < len(slot.Processors) && slot.Processors[cpu] >= 0 {
+					label = strconv.Itoa(slot.Processors[cpu])
+				}
+				fmt.Fprintf(&b, "%-*s", cell+1, label)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
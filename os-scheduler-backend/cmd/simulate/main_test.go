@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os-scheduler-backend/models"
+	"os-scheduler-backend/services"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// runFixture 在默认系统参数下模拟一个小负载
+func runFixture(t *testing.T, policy models.SchedulingPolicy) *models.SimulationResult {
+	t.Helper()
+	workload, err := services.ParseWorkload(strings.NewReader("id,name,burst,priority,predecessors\na,a,3,5,\nb,b,2,1,a\n"), services.WorkloadCSV)
+	if err != nil {
+		t.Fatalf("ParseWorkload: %v", err)
+	}
+	workload.Name = "fixture"
+	cfg := services.DefaultConfig()
+	cfg.ProcessorCount = 1
+	cfg.Policy = policy
+	result, err := services.RunWorkload(workload, cfg, services.DefaultMaxTicks)
+	if err != nil {
+		t.Fatalf("RunWorkload: %v", err)
+	}
+	return result
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeText(&buf, runFixture(t, models.PolicyFCFS)); err != nil {
+		t.Fatalf("writeText: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"负载: fixture", "调度策略: fcfs", "总时长: 5  完成进程: 2/2", "CPU0  1 1 1 2 2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text output lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteCSVAndJSON(t *testing.T) {
+	result := runFixture(t, models.PolicyFCFS)
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, result); err != nil {
+		t.Fatalf("writeCSV: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	want := [][]string{
+		{"pid", "name", "arrival", "start", "finish", "burst", "turnaround", "waiting", "response"},
+		{"1", "a", "0", "0", "3", "3", "3", "0", "0"},
+		{"2", "b", "0", "3", "5", "2", "5", "3", "3"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("CSV = %v, want %v", records, want)
+	}
+
+	buf.Reset()
+	if err := writeJSON(&buf, result); err != nil {
+		t.Fatalf("writeJSON: %v", err)
+	}
+	var decoded models.SimulationResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("decoding JSON: %v", err)
+	}
+	if decoded.Workload != "fixture" || decoded.Mapping["b"] != 2 || len(decoded.Timeline) != 5 {
+		t.Errorf("JSON result = %+v", decoded)
+	}
+}
+
+func TestWriteComparison(t *testing.T) {
+	rows := []models.ComparisonRow{
+		{Policy: models.PolicyFCFS, Allocator: models.FirstFit, Makespan: 5},
+		{Policy: "random", Allocator: models.FirstFit, Error: "不支持的调度策略"},
+	}
+	for _, format := range []string{"text", "json", "csv"} {
+		var buf bytes.Buffer
+		if err := writeComparison(&buf, format, rows); err != nil {
+			t.Fatalf("writeComparison(%s): %v", format, err)
+		}
+		if !strings.Contains(buf.String(), "不支持的调度策略") {
+			t.Errorf("%s comparison lacks the failed row:\n%s", format, buf.String())
+		}
+	}
+	if err := writeComparison(&bytes.Buffer{}, "xml", rows); err == nil {
+		t.Error("writeComparison accepted an unknown format")
+	}
+}
+
+func TestRenderGanttWrapsLines(t *testing.T) {
+	timeline := make([]models.TimelineSlot, ganttWidth+2)
+	for i := range timeline {
+		timeline[i] = models.TimelineSlot{Tick: i, Processors: []int{12, -1}}
+	}
+	lines := strings.Split(strings.TrimRight(renderGantt(timeline), "\n"), "\n")
+	// 每行ganttWidth个时钟周期：刻度行和每个处理机一行，之间空一行
+	if len(lines) != 7 {
+		t.Fatalf("%d lines, want 7:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	if !strings.HasPrefix(lines[1], "CPU0  12 12 ") || !strings.HasPrefix(lines[2], "CPU1  .  .  ") {
+		t.Errorf("processor lines = %q, %q", lines[1], lines[2])
+	}
+	if !strings.HasPrefix(lines[4], "tick  40") {
+		t.Errorf("second block starts with %q, want tick 40", lines[4])
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	if got := splitList(" rr, ,fcfs,"); !reflect.DeepEqual(got, []string{"rr", "fcfs"}) {
+		t.Errorf("splitList = %v", got)
+	}
+}
@@ -15,36 +15,51 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/process": {
+        "/compare": {
             "post": {
-                "description": "添加一个新的进程到系统中",
+                "description": "对每种调度策略与内存分配算法的组合，在独立的调度器和内存管理器上运行同一负载，返回各组合的平均等待时间、周转时间、响应时间、吞吐量和处理机利用率。不影响当前系统状态。一次最多对比20种组合，每种组合最多模拟20000个时钟周期",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "添加新进程",
+                "summary": "对比调度策略",
                 "parameters": [
                     {
-                        "description": "进程信息",
-                        "name": "process",
+                        "description": "负载及要对比的策略",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.PCB"
+                            "$ref": "#/definitions/main.CompareRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "对比完成",
                         "schema": {
-                            "$ref": "#/definitions/models.PCB"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.ComparisonRow"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/main.Response"
                         }
@@ -52,16 +67,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/processor-status": {
+        "/config": {
             "get": {
-                "description": "获取所有处理机的当前运行状态，包括每个处理机上正在运行的进程信息",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "获取处理机状态",
+                "tags": [
+                    "system"
+                ],
+                "summary": "获取系统参数",
                 "responses": {
                     "200": {
-                        "description": "获取处理机状态成功",
+                        "description": "成功获取系统参数",
                         "schema": {
                             "allOf": [
                                 {
@@ -71,7 +88,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/main.ProcessorStatusResponse"
+                                            "$ref": "#/definitions/models.SystemConfig"
                                         }
                                     }
                                 }
@@ -79,11 +96,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/reset": {
-            "post": {
-                "description": "强制重启整个系统，清空所有进程和内存。这将终止所有正在运行的进程，释放所有内存分配，并将系统恢复到初始状态。系统参数（如处理机数量、最大进程数）将保持不变。",
+            },
+            "put": {
+                "description": "请求中未出现的参数保持不变，用户组份额 groupShares 出现时整体替换。道数、调度策略、时间片和内存分配算法立即生效；修改处理机数量或内存大小需要重置系统，未指定 reset=true 时返回409及需要重置的参数列表。指定 reset=true 时总是先清空所有进程",
                 "consumes": [
                     "application/json"
                 ],
@@ -93,48 +108,133 @@ const docTemplate = `{
                 "tags": [
                     "system"
                 ],
-                "summary": "重置系统",
+                "summary": "修改系统参数",
+                "parameters": [
+                    {
+                        "description": "系统参数",
+                        "name": "config",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SystemConfig"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否重置系统后应用",
+                        "name": "reset",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "系统重置成功",
+                        "description": "修改成功",
                         "schema": {
-                            "$ref": "#/definitions/main.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SystemConfig"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "500": {
-                        "description": "系统重置失败",
+                    "400": {
+                        "description": "无效的系统参数",
                         "schema": {
                             "$ref": "#/definitions/main.Response"
                         }
+                    },
+                    "409": {
+                        "description": "需要重置系统才能修改的参数",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
                 }
             }
         },
-        "/resume/{pid}": {
+        "/gangs": {
+            "get": {
+                "description": "获取所有进程组及其成员，按名称排序",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "获取进程组",
+                "responses": {
+                    "200": {
+                        "description": "获取进程组成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Gang"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "恢复已挂起的进程，使其重新参与调度",
+                "description": "把一组进程声明为进程组，组内进程只在同一个时钟周期内同时占用不同的处理机时才运行，组内任一进程让出处理机时整组让出。同名的进程组已存在时替换其成员，组内进程数不能超过处理机数量和道数，组内进程之间不能有前驱关系，各进程的亲和性要允许它们同时在不同的处理机上运行",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "恢复进程",
+                "summary": "声明进程组",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "进程ID",
-                        "name": "pid",
-                        "in": "path",
-                        "required": true
+                        "description": "进程组名称和成员",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.GangRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "进程恢复成功",
+                        "description": "声明成功",
                         "schema": {
                             "$ref": "#/definitions/main.Response"
                         }
                     },
                     "400": {
-                        "description": "进程恢复失败",
+                        "description": "声明失败",
                         "schema": {
                             "$ref": "#/definitions/main.Response"
                         }
@@ -142,16 +242,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/schedule": {
-            "post": {
-                "description": "执行一次进程调度，更新进程状态和处理机分配",
+        "/graph": {
+            "get": {
+                "description": "获取所有进程构成的前驱图，包括各进程的当前状态、前驱到后继的边以及按总运行时间计算的关键路径。format=dot 时返回Graphviz DOT文本",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/plain"
+                ],
+                "summary": "获取前驱图",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "输出格式：json（默认）或 dot",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "执行调度",
                 "responses": {
                     "200": {
-                        "description": "调度执行成功",
+                        "description": "获取前驱图成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -161,26 +270,32 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ProcessQueue"
+                                            "$ref": "#/definitions/models.PrecedenceGraph"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "不支持的格式",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
                     }
                 }
             }
         },
-        "/status": {
+        "/locks": {
             "get": {
-                "description": "获取当前系统的状态信息，包括进程队列和内存管理状态",
+                "description": "获取进程使用或持有的所有锁的持有者、等待者和天花板优先数，按锁名排序",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "获取系统状态",
+                "summary": "获取锁",
                 "responses": {
                     "200": {
-                        "description": "获取状态成功",
+                        "description": "获取锁成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -190,7 +305,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/main.StatusResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Lock"
+                                            }
                                         }
                                     }
                                 }
@@ -200,13 +318,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/suspend/{pid}": {
+        "/process": {
             "post": {
-                "description": "将指定进程挂起，暂停其执行",
+                "description": "添加一个新的进程到系统中。前驱进程必须已存在，不能以自身为前驱，也不能构成环；已完成的前驱视为已满足",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "挂起进程",
+                "summary": "添加新进程",
+                "parameters": [
+                    {
+                        "description": "进程信息",
+                        "name": "process",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PCB"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PCB"
+                        }
+                    },
+                    "400": {
+                        "description": "前驱关系无效或内存分配失败",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.PrecedenceError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/process/{pid}/affinity": {
+            "put": {
+                "description": "设置进程允许运行的处理机，掩码第i位为1表示允许在处理机i上运行，0表示不限制。正在运行的进程若不再允许在当前处理机上运行，下一次调度时让出处理机",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "设置进程亲和性",
                 "parameters": [
                     {
                         "type": "integer",
@@ -214,196 +384,3024 @@ const docTemplate = `{
                         "name": "pid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "亲和性掩码",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.AffinityRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "进程挂起成功",
+                        "description": "设置成功",
                         "schema": {
                             "$ref": "#/definitions/main.Response"
                         }
                     },
                     "400": {
-                        "description": "进程挂起失败",
+                        "description": "设置失败",
                         "schema": {
                             "$ref": "#/definitions/main.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "main.ProcessorStatusResponse": {
-            "type": "object",
-            "properties": {
-                "processors": {
-                    "description": "每个处理机当前运行的进程，如果没有则为nil",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.PCB"
+        },
+        "/process/{pid}/history": {
+            "get": {
+                "description": "按时间先后返回进程的每一次状态转换：生效的时钟周期、原状态（为空表示刚创建）、新状态和原因",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "获取进程状态历史",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "进程ID",
+                        "name": "pid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "获取状态历史成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.StateChange"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "无效的进程ID",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "找不到进程",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
                     }
                 }
             }
         },
-        "main.Response": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "description": "状态码，0表示成功，非0表示错误",
-                    "type": "integer"
-                },
-                "data": {
-                    "description": "数据，可以为空"
-                },
-                "message": {
-                    "description": "提示信息",
-                    "type": "string"
+        "/process/{pid}/tickets": {
+            "put": {
+                "description": "设置进程在彩票调度和步长调度中持有的彩票数，0表示默认值100，从下一次调度开始生效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "设置进程彩票数",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "进程ID",
+                        "name": "pid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "彩票数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.TicketsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "设置成功",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "设置失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/processes/batch": {
+            "post": {
+                "description": "一次提交整个任务依赖图。进程通过批内ID互相引用前驱，也可以通过predecessorPids引用系统中已存在的进程。所有进程的内存要么全部分配成功，要么全部不分配",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "批量添加进程",
+                "parameters": [
+                    {
+                        "description": "批量进程信息",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.BatchProcessRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "批量添加成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.BatchProcessResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "前驱关系无效或内存分配失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/processor-status": {
+            "get": {
+                "description": "获取所有处理机的当前运行状态，包括每个处理机上正在运行的进程信息",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "获取处理机状态",
+                "responses": {
+                    "200": {
+                        "description": "获取处理机状态成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.ProcessorStatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/processors": {
+            "put": {
+                "description": "在运行中增减处理机。被移除的处理机上运行的进程被抢占回就绪队列，新增的处理机从下一次调度开始接收进程，变化记录在时间线事件中",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "修改处理机数量",
+                "parameters": [
+                    {
+                        "description": "新的处理机数量",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ProcessorCountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.StatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "无效的处理机数量",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/replay": {
+            "post": {
+                "description": "清空系统后依次重放请求体中的JSONL事件日志，重建与记录时完全相同的状态。每个调用的结果都会与日志比对，不一致时停止重放",
+                "consumes": [
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "重放事件日志",
+                "parameters": [
+                    {
+                        "description": "JSONL格式的事件日志，每行一条事件",
+                        "name": "log",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "重放成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.StatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "重放失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reset": {
+            "post": {
+                "description": "强制重启整个系统，清空所有进程和内存。这将终止所有正在运行的进程，释放所有内存分配，并将系统恢复到初始状态。系统参数（如处理机数量、最大进程数）将保持不变。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "重置系统",
+                "responses": {
+                    "200": {
+                        "description": "系统重置成功",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "系统重置失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/restore": {
+            "post": {
+                "description": "用请求体中的快照恢复系统状态；请求体为空或未提供快照时从服务端快照文件恢复",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "恢复快照",
+                "parameters": [
+                    {
+                        "description": "要恢复的快照",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/main.RestoreRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "快照恢复成功",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "快照恢复失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/resume/{pid}": {
+            "post": {
+                "description": "恢复已挂起的进程，使其重新参与调度：挂起就绪的进程回到就绪队列（道数已满时进入后备队列），挂起阻塞的进程回到等待队列",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "恢复进程",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "进程ID",
+                        "name": "pid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "进程恢复成功",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "进程恢复失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/rewind": {
+            "post": {
+                "description": "回退指定次数的调度，恢复到对应调度执行前的进程队列和内存布局。历史记录不足时回退到最早的记录",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "回退调度",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "回退步数，默认为1",
+                        "name": "steps",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "回退成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.RewindResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "回退失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/rt/analysis": {
+            "get": {
+                "description": "对当前的实时任务集做单处理机可调度性分析：速率单调调度的Liu-Layland利用率界和响应时间分析，以及最早截止时间优先的利用率测试",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "可调度性分析",
+                "responses": {
+                    "200": {
+                        "description": "分析完成",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SchedulabilityReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/rt/tasks": {
+            "get": {
+                "description": "获取所有实时任务及其下一次释放时刻和已释放的作业数",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "获取实时任务",
+                "responses": {
+                    "200": {
+                        "description": "获取实时任务成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.RealTimeTask"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "添加周期或偶发实时任务。周期任务从当前时钟加上相位开始每个周期释放一个作业，偶发任务通过 /rt/tasks/{id}/release 释放作业；作业的运行时间为最坏执行时间，截止时刻为释放时刻加上相对截止时间（0表示等于周期）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "添加实时任务",
+                "parameters": [
+                    {
+                        "description": "实时任务，id、nextRelease、jobs由系统填写",
+                        "name": "task",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RealTimeTask"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "添加成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RealTimeTask"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "任务参数无效",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/rt/tasks/{id}": {
+            "delete": {
+                "description": "删除实时任务，不再释放新的作业，已释放的作业继续运行",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "删除实时任务",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "删除失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/rt/tasks/{id}/release": {
+            "post": {
+                "description": "在当前时钟释放偶发任务的一个作业，距上次释放不足最小间隔（周期）时失败",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "释放偶发作业",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "任务ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "释放成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.JobReleaseResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "释放失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/scenarios/pathfinder": {
+            "post": {
+                "description": "重置系统为单处理机、动态优先数调度和指定的锁协议，然后提交重现优先级反转的负载：低优先数的 meteo 持有锁 bus 时，高优先数的 bus_dist 到达并等待该锁，中优先数的 comms 随后到达。其余系统参数保持不变",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "加载火星探路者号场景",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "锁协议：none、inheritance、ceiling，默认为none",
+                        "name": "protocol",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "场景已加载",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.WorkloadSubmitResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "加载失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule": {
+            "post": {
+                "description": "执行一次进程调度，更新进程状态和处理机分配",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "执行调度",
+                "responses": {
+                    "200": {
+                        "description": "调度执行成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ProcessQueue"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions": {
+            "get": {
+                "description": "按创建时间列出所有会话",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "获取会话列表",
+                "responses": {
+                    "200": {
+                        "description": "成功获取会话列表",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SessionInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建一个拥有独立调度器和内存管理器的模拟会话。会话ID为空时自动生成，系统参数为空时使用默认参数。创建后在接口路径前加上 /sessions/{id} 即可操作该会话",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "创建会话",
+                "parameters": [
+                    {
+                        "description": "会话ID和系统参数",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/main.SessionCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SessionInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "创建失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "获取会话信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功获取会话信息",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SessionInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "会话不存在",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除会话及其全部状态，默认会话不能删除",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "session"
+                ],
+                "summary": "删除会话",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "删除失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/shares": {
+            "get": {
+                "description": "比较各进程实际占用的处理机时间与按彩票数应得的处理机时间。每个时钟周期的处理机时间按彩票数分给当时就绪和运行中的进程，每个进程最多得到一个处理机",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "获取比例份额",
+                "responses": {
+                    "200": {
+                        "description": "获取比例份额成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ShareReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshot": {
+            "post": {
+                "description": "将调度器（各进程队列、下一个PID、时钟、系统参数）和内存分区状态保存到服务端快照文件，并在响应中返回快照内容",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "保存快照",
+                "responses": {
+                    "200": {
+                        "description": "快照保存成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Snapshot"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "快照保存失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "获取各进程的到达、开始、完成时刻以及周转时间、等待时间、响应时间，和系统的平均指标、吞吐量、处理机利用率",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "获取调度统计",
+                "responses": {
+                    "200": {
+                        "description": "获取统计成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Stats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "description": "获取当前系统的状态信息，包括进程队列和内存管理状态",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "获取系统状态",
+                "responses": {
+                    "200": {
+                        "description": "获取状态成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.StatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/suspend/{pid}": {
+            "post": {
+                "description": "将就绪、运行、后备或等待队列中的进程挂起，暂停其执行。等待中的进程转为挂起阻塞，其余转为挂起就绪",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "挂起进程",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "进程ID",
+                        "name": "pid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "进程挂起成功",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "进程挂起失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/timeline": {
+            "get": {
+                "description": "获取每个时钟周期各处理机上运行的进程，可用于绘制甘特图",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "获取时间线",
+                "responses": {
+                    "200": {
+                        "description": "获取时间线成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.TimelineSlot"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/timeline/events": {
+            "get": {
+                "description": "获取时间线上的系统事件，如处理机数量变化，tick 为事件发生后的第一个时钟周期",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "获取时间线事件",
+                "responses": {
+                    "200": {
+                        "description": "获取时间线事件成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.TimelineEvent"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/workload/export": {
+            "get": {
+                "description": "将当前各队列中的进程导出为与导入相同格式的负载，到达时间以最早到达的进程为0",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "summary": "导出负载",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "负载格式：json（默认）或 csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "负载名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否导出已完成的进程",
+                        "name": "includeFinished",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "负载内容",
+                        "schema": {
+                            "$ref": "#/definitions/models.Workload"
+                        }
+                    },
+                    "400": {
+                        "description": "不支持的格式",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/workload/generate": {
+            "post": {
+                "description": "按指定的运行时间、优先数、内存大小分布，泊松到达过程和前驱图密度生成一组进程并提交到系统。相同的参数和种子总是生成相同的负载；进程到达时刻以当前时钟为基准。最多生成10000个进程，前驱图的期望边数不能超过100000",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "生成合成负载",
+                "parameters": [
+                    {
+                        "description": "生成参数",
+                        "name": "config",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GeneratorConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "负载已提交",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.WorkloadSubmitResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "生成或提交失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/workload/import": {
+            "post": {
+                "description": "导入JSON或CSV格式的负载并提交到系统，格式说明见 readme。未指定format时，Content-Type为text/csv按CSV解析，否则按JSON解析；进程到达时刻以当前时钟为基准",
+                "consumes": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "导入负载",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "负载格式：json 或 csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "负载名称，CSV格式不包含名称时使用",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "description": "负载内容",
+                        "name": "workload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Workload"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "负载已提交",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.WorkloadSubmitResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "解析或提交失败",
+                        "schema": {
+                            "$ref": "#/definitions/main.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "main.AffinityRequest": {
+            "type": "object",
+            "properties": {
+                "mask": {
+                    "description": "第i位为1表示允许在处理机i上运行，0表示不限制",
+                    "type": "integer"
+                }
+            }
+        },
+        "main.BatchProcessRequest": {
+            "type": "object",
+            "properties": {
+                "processes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchProcess"
+                    }
+                }
+            }
+        },
+        "main.BatchProcessResponse": {
+            "type": "object",
+            "properties": {
+                "mapping": {
+                    "description": "批内ID到PID的映射",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "processes": {
+                    "description": "创建的进程，顺序与请求一致",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PCB"
+                    }
+                }
+            }
+        },
+        "main.CompareRequest": {
+            "type": "object",
+            "properties": {
+                "allocators": {
+                    "description": "为空时使用当前内存分配算法",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AllocationAlgorithm"
+                    }
+                },
+                "config": {
+                    "description": "为空时使用当前系统参数",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SystemConfig"
+                        }
+                    ]
+                },
+                "maxTicks": {
+                    "description": "为0时使用上限 services.MaxCompareTicks",
+                    "type": "integer"
+                },
+                "policies": {
+                    "description": "为空时使用当前调度策略",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SchedulingPolicy"
+                    }
+                },
+                "workload": {
+                    "$ref": "#/definitions/models.Workload"
+                }
+            }
+        },
+        "main.GangRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "pids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "main.JobReleaseResponse": {
+            "type": "object",
+            "properties": {
+                "pid": {
+                    "description": "作业的PID",
+                    "type": "integer"
+                }
+            }
+        },
+        "main.ProcessorCountRequest": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "main.ProcessorStatusResponse": {
+            "type": "object",
+            "properties": {
+                "processors": {
+                    "description": "每个处理机当前运行的进程，如果没有则为nil",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PCB"
+                    }
+                },
+                "specs": {
+                    "description": "每个处理机的速度和类型",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProcessorSpec"
+                    }
+                }
+            }
+        },
+        "main.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "状态码，0表示成功，非0表示错误",
+                    "type": "integer"
+                },
+                "data": {
+                    "description": "数据，可以为空"
+                },
+                "message": {
+                    "description": "提示信息",
+                    "type": "string"
+                }
+            }
+        },
+        "main.RestoreRequest": {
+            "type": "object",
+            "properties": {
+                "snapshot": {
+                    "description": "要恢复的快照，为空时从快照文件恢复",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Snapshot"
+                        }
+                    ]
+                }
+            }
+        },
+        "main.RewindResponse": {
+            "type": "object",
+            "properties": {
+                "clock": {
+                    "description": "回退后的时钟",
+                    "type": "integer"
+                },
+                "queue": {
+                    "$ref": "#/definitions/models.ProcessQueue"
+                },
+                "remaining": {
+                    "description": "还可以继续回退的步数",
+                    "type": "integer"
+                },
+                "steps": {
+                    "description": "实际回退的步数",
+                    "type": "integer"
+                }
+            }
+        },
+        "main.SessionCreateRequest": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "description": "为空时使用默认系统参数",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SystemConfig"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "为空时自动生成",
+                    "type": "string"
+                }
+            }
+        },
+        "main.StatusResponse": {
+            "type": "object",
+            "properties": {
+                "memory": {
+                    "$ref": "#/definitions/models.MemoryManager"
+                },
+                "queue": {
+                    "$ref": "#/definitions/models.ProcessQueue"
+                }
+            }
+        },
+        "main.TicketsRequest": {
+            "type": "object",
+            "properties": {
+                "tickets": {
+                    "description": "0表示默认值，最多 services.MaxTickets 张",
+                    "type": "integer"
+                }
+            }
+        },
+        "main.WorkloadSubmitResponse": {
+            "type": "object",
+            "properties": {
+                "mapping": {
+                    "description": "负载内ID到PID的映射",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "workload": {
+                    "$ref": "#/definitions/models.Workload"
+                }
+            }
+        },
+        "models.AllocationAlgorithm": {
+            "type": "string",
+            "enum": [
+                "first_fit",
+                "next_fit",
+                "best_fit",
+                "worst_fit"
+            ],
+            "x-enum-comments": {
+                "BestFit": "最佳适应",
+                "FirstFit": "首次适应",
+                "NextFit": "循环首次适应",
+                "WorstFit": "最坏适应"
+            },
+            "x-enum-varnames": [
+                "FirstFit",
+                "NextFit",
+                "BestFit",
+                "WorstFit"
+            ]
+        },
+        "models.BatchProcess": {
+            "type": "object",
+            "properties": {
+                "affinityMask": {
+                    "description": "允许运行的处理机位掩码，0表示不限制",
+                    "type": "integer"
+                },
+                "criticalSections": {
+                    "description": "临界区",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CriticalSection"
+                    }
+                },
+                "gang": {
+                    "description": "所属进程组，组内进程同时运行",
+                    "type": "string"
+                },
+                "group": {
+                    "description": "所属用户组，为空表示默认组",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "批内ID，在同一批次中唯一",
+                    "type": "string"
+                },
+                "memorySize": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "predecessorPids": {
+                    "description": "系统中已存在的前驱PID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "predecessors": {
+                    "description": "批内前驱ID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "requiredTime": {
+                    "type": "integer"
+                },
+                "tickets": {
+                    "description": "比例份额调度的彩票数，0表示默认值",
+                    "type": "integer"
+                },
+                "user": {
+                    "description": "所属用户，为空表示默认用户",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ComparisonRow": {
+            "type": "object",
+            "properties": {
+                "allocator": {
+                    "$ref": "#/definitions/models.AllocationAlgorithm"
+                },
+                "avgResponse": {
+                    "type": "number"
+                },
+                "avgTurnaround": {
+                    "type": "number"
+                },
+                "avgWaiting": {
+                    "type": "number"
+                },
+                "contextSwitches": {
+                    "type": "integer"
+                },
+                "cpuUtilization": {
+                    "type": "number"
+                },
+                "effectiveUtilization": {
+                    "description": "扣除上下文切换开销后的处理机利用率",
+                    "type": "number"
+                },
+                "error": {
+                    "description": "模拟失败的原因",
+                    "type": "string"
+                },
+                "makespan": {
+                    "description": "所有进程完成所用的时钟周期数",
+                    "type": "integer"
+                },
+                "policy": {
+                    "$ref": "#/definitions/models.SchedulingPolicy"
+                },
+                "throughput": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CriticalSection": {
+            "type": "object",
+            "properties": {
+                "length": {
+                    "description": "临界区内的工作量",
+                    "type": "integer"
+                },
+                "lock": {
+                    "description": "锁名",
+                    "type": "string"
+                },
+                "start": {
+                    "description": "进入临界区时已完成的工作量",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Distribution": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "longMean": {
+                    "type": "number"
+                },
+                "longRatio": {
+                    "type": "number"
+                },
+                "max": {
+                    "type": "integer"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Gang": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "pids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.GangStats": {
+            "type": "object",
+            "properties": {
+                "finish": {
+                    "description": "最后一个进程的完成时刻",
+                    "type": "integer"
+                },
+                "finished": {
+                    "description": "组内进程是否都已完成",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "turnaround": {
+                    "description": "从最早到达到最后完成的时间，仅对已完成的组有效",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GeneratorConfig": {
+            "type": "object",
+            "properties": {
+                "arrivalRate": {
+                    "description": "泊松到达过程中每个时钟周期平均到达的进程数，为0时所有进程同时到达",
+                    "type": "number"
+                },
+                "burst": {
+                    "description": "运行时间分布",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Distribution"
+                        }
+                    ]
+                },
+                "count": {
+                    "description": "进程数",
+                    "type": "integer"
+                },
+                "dagDensity": {
+                    "description": "任意两个进程之间存在前驱关系的概率",
+                    "type": "number"
+                },
+                "memory": {
+                    "description": "内存大小分布",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Distribution"
+                        }
+                    ]
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priority": {
+                    "description": "优先数分布",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Distribution"
+                        }
+                    ]
+                },
+                "seed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GraphEdge": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GraphNode": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "onCriticalPath": {
+                    "type": "boolean"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "remainingTime": {
+                    "type": "integer"
+                },
+                "state": {
+                    "$ref": "#/definitions/models.ProcessState"
+                },
+                "totalTime": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GroupStats": {
+            "type": "object",
+            "properties": {
+                "cpuTime": {
+                    "description": "组内进程占用处理机的时钟周期数之和",
+                    "type": "integer"
+                },
+                "expectedShare": {
+                    "description": "份额占有进程的各组份额之和的比例",
+                    "type": "number"
+                },
+                "finished": {
+                    "description": "组内已完成的进程数",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "processes": {
+                    "description": "组内进程数",
+                    "type": "integer"
+                },
+                "shares": {
+                    "description": "配置的份额",
+                    "type": "integer"
+                },
+                "usage": {
+                    "description": "占所有进程处理机时间的比例",
+                    "type": "number"
+                }
+            }
+        },
+        "models.Lock": {
+            "type": "object",
+            "properties": {
+                "ceiling": {
+                    "description": "天花板优先数：会使用该锁的未完成进程的最大基础优先数",
+                    "type": "integer"
+                },
+                "holder": {
+                    "description": "持有锁的进程，0表示空闲",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "waiters": {
+                    "description": "因等待该锁阻塞的进程，按阻塞先后排序",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.LockProtocol": {
+            "type": "string",
+            "enum": [
+                "none",
+                "inheritance",
+                "ceiling"
+            ],
+            "x-enum-comments": {
+                "LockCeiling": "优先级天花板：持有者获得锁后立即提升到锁的天花板优先数",
+                "LockInheritance": "优先级继承：持有者继承等待该锁的进程中的最大优先数",
+                "LockNone": "不调整优先数，可能发生优先级反转"
+            },
+            "x-enum-varnames": [
+                "LockNone",
+                "LockInheritance",
+                "LockCeiling"
+            ]
+        },
+        "models.MemoryBlock": {
+            "type": "object",
+            "properties": {
+                "isUsed": {
+                    "type": "boolean"
+                },
+                "length": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MemoryManager": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "$ref": "#/definitions/models.AllocationAlgorithm"
+                },
+                "blocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MemoryBlock"
+                    }
+                },
+                "nextFitStart": {
+                    "description": "循环首次适应下次开始查找的地址",
+                    "type": "integer"
+                },
+                "osSize": {
+                    "type": "integer"
+                },
+                "totalSize": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PCB": {
+            "type": "object",
+            "properties": {
+                "affinityMask": {
+                    "description": "允许运行的处理机位掩码，0表示不限制",
+                    "type": "integer"
+                },
+                "arrivalTime": {
+                    "description": "到达时刻",
+                    "type": "integer"
+                },
+                "blockedOn": {
+                    "description": "正在等待的锁，为空表示未因锁阻塞",
+                    "type": "string"
+                },
+                "boost": {
+                    "description": "锁协议临时提高的优先数，优先数减去它为基础优先数",
+                    "type": "integer"
+                },
+                "cpuTime": {
+                    "description": "已占用处理机的时间",
+                    "type": "integer"
+                },
+                "criticalSections": {
+                    "description": "按开始位置排序、互不重叠的临界区",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CriticalSection"
+                    }
+                },
+                "deadline": {
+                    "description": "实时作业的绝对截止时刻",
+                    "type": "integer"
+                },
+                "deadlineMissed": {
+                    "description": "实时作业是否错过了截止时刻",
+                    "type": "boolean"
+                },
+                "entitled": {
+                    "description": "按彩票数应得的处理机时间",
+                    "type": "number"
+                },
+                "fairPass": {
+                    "description": "公平份额调度的行程值",
+                    "type": "integer"
+                },
+                "finishTime": {
+                    "description": "完成时刻",
+                    "type": "integer"
+                },
+                "gang": {
+                    "description": "所属进程组，为空表示不属于任何组",
+                    "type": "string"
+                },
+                "group": {
+                    "description": "所属用户组，为空表示默认组",
+                    "type": "string"
+                },
+                "heldLock": {
+                    "description": "当前持有的锁，为空表示未持有",
+                    "type": "string"
+                },
+                "history": {
+                    "description": "状态转换历史",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StateChange"
+                    }
+                },
+                "initialPriority": {
+                    "description": "提交时的优先数，导出负载时使用",
+                    "type": "integer"
+                },
+                "lastProcessor": {
+                    "description": "上次运行的处理机，-1表示尚未运行",
+                    "type": "integer"
+                },
+                "lockWait": {
+                    "description": "因等待锁阻塞的时钟周期数",
+                    "type": "integer"
+                },
+                "maxWait": {
+                    "description": "最长的一次连续等待",
+                    "type": "integer"
+                },
+                "memorySize": {
+                    "type": "integer"
+                },
+                "memoryStart": {
+                    "type": "integer"
+                },
+                "migrations": {
+                    "description": "换到另一个处理机上运行的次数",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pass": {
+                    "description": "步长调度的行程值，每运行一个时钟周期增加一个步长",
+                    "type": "integer"
+                },
+                "period": {
+                    "description": "实时作业所属任务的周期",
+                    "type": "integer"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "predecessors": {
+                    "description": "前驱进程PID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "processorId": {
+                    "description": "-1表示未分配处理机",
+                    "type": "integer"
+                },
+                "quantumUsed": {
+                    "description": "本次占用处理机已用的时间片",
+                    "type": "integer"
+                },
+                "requiredTime": {
+                    "description": "剩余运行时间",
+                    "type": "integer"
+                },
+                "runQueue": {
+                    "description": "按处理机划分就绪队列时所在的队列，-1表示未分配",
+                    "type": "integer"
+                },
+                "stallTicks": {
+                    "description": "剩余的迁移开销，期间占用处理机但不推进",
+                    "type": "integer"
+                },
+                "startTime": {
+                    "description": "首次运行时刻，-1表示尚未运行",
+                    "type": "integer"
+                },
+                "state": {
+                    "$ref": "#/definitions/models.ProcessState"
+                },
+                "successors": {
+                    "description": "后继进程PID列表",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "switchTicks": {
+                    "description": "剩余的上下文切换开销，期间占用处理机但不推进，不计入时间片",
+                    "type": "integer"
+                },
+                "taskId": {
+                    "description": "所属实时任务，0表示不是实时作业",
+                    "type": "integer"
+                },
+                "tickets": {
+                    "description": "比例份额调度的彩票数，0表示默认值",
+                    "type": "integer"
+                },
+                "totalTime": {
+                    "description": "总运行时间",
+                    "type": "integer"
+                },
+                "user": {
+                    "description": "所属用户，为空表示默认用户",
+                    "type": "string"
+                },
+                "vruntime": {
+                    "description": "按权重折算的虚拟运行时间，单位为nice 0进程的千分之一时钟周期",
+                    "type": "integer"
+                },
+                "waitTicks": {
+                    "description": "在就绪或后备队列中连续等待的时钟周期数",
+                    "type": "integer"
+                },
+                "workCarry": {
+                    "description": "不足一个时间单位的已完成工作量，单位为千分之一",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PrecedenceGraph": {
+            "type": "object",
+            "properties": {
+                "criticalPath": {
+                    "description": "关键路径上的PID，按执行顺序排列",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "criticalPathLength": {
+                    "description": "关键路径上各进程总运行时间之和",
+                    "type": "integer"
+                },
+                "edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GraphEdge"
+                    }
+                },
+                "nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GraphNode"
+                    }
+                }
+            }
+        },
+        "models.ProcessQueue": {
+            "type": "object",
+            "properties": {
+                "backup": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PCB"
+                    }
+                },
+                "finished": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PCB"
+                    }
+                },
+                "new": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PCB"
+                    }
+                },
+                "ready": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PCB"
+                    }
+                },
+                "running": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PCB"
+                    }
+                },
+                "suspended": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PCB"
+                    }
+                },
+                "waiting": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PCB"
+                    }
+                }
+            }
+        },
+        "models.ProcessShare": {
+            "type": "object",
+            "properties": {
+                "achievedShare": {
+                    "description": "实际获得的处理机时间占所有进程的比例",
+                    "type": "number"
+                },
+                "cpuTime": {
+                    "description": "实际占用处理机的时钟周期数",
+                    "type": "integer"
+                },
+                "entitled": {
+                    "description": "按彩票数应得的时钟周期数",
+                    "type": "number"
+                },
+                "expectedShare": {
+                    "description": "应得的处理机时间占所有进程的比例",
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "ratio": {
+                    "description": "实际与应得之比，1表示恰好得到应得的份额",
+                    "type": "number"
+                },
+                "tickets": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProcessState": {
+            "type": "string",
+            "enum": [
+                "new",
+                "ready",
+                "running",
+                "waiting",
+                "finished",
+                "suspended_ready",
+                "suspended_blocked"
+            ],
+            "x-enum-comments": {
+                "New": "已提交但尚未到达或尚未分配到内存",
+                "SuspendedBlocked": "挂起前在等待队列中，恢复后仍需等待",
+                "SuspendedReady": "挂起前就绪或运行，恢复后回到就绪队列"
+            },
+            "x-enum-varnames": [
+                "New",
+                "Ready",
+                "Running",
+                "Waiting",
+                "Finished",
+                "SuspendedReady",
+                "SuspendedBlocked"
+            ]
+        },
+        "models.ProcessStats": {
+            "type": "object",
+            "properties": {
+                "arrival": {
+                    "type": "integer"
+                },
+                "burst": {
+                    "type": "integer"
+                },
+                "contextSwitches": {
+                    "description": "处理机从其他进程切换到该进程的次数",
+                    "type": "integer"
+                },
+                "cpuTime": {
+                    "type": "integer"
+                },
+                "finish": {
+                    "type": "integer"
+                },
+                "finished": {
+                    "type": "boolean"
+                },
+                "lockWait": {
+                    "description": "因等待锁阻塞的时钟周期数",
+                    "type": "integer"
+                },
+                "maxWait": {
+                    "description": "在就绪或后备队列中最长的一次连续等待",
+                    "type": "integer"
+                },
+                "migrations": {
+                    "description": "换到另一个处理机上运行的次数",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pid": {
+                    "type": "integer"
+                },
+                "response": {
+                    "description": "响应时间，仅对已运行过的进程有效",
+                    "type": "integer"
+                },
+                "start": {
+                    "description": "-1表示尚未运行",
+                    "type": "integer"
+                },
+                "turnaround": {
+                    "description": "周转时间，仅对已完成进程有效",
+                    "type": "integer"
+                },
+                "waiting": {
+                    "description": "等待时间，仅对已完成进程有效",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProcessorSpec": {
+            "type": "object",
+            "properties": {
+                "speed": {
+                    "description": "每个时钟周期完成的工作量，0表示1",
+                    "type": "number"
+                },
+                "type": {
+                    "description": "处理机类型，如 big、little",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProcessorStats": {
+            "type": "object",
+            "properties": {
+                "busyTicks": {
+                    "description": "有进程运行的时钟周期数",
+                    "type": "integer"
+                },
+                "effectiveUtilization": {
+                    "description": "扣除上下文切换开销后的忙碌时间占比",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "overheadTicks": {
+                    "description": "用于上下文切换的时钟周期数，计入忙碌时间",
+                    "type": "integer"
+                },
+                "speed": {
+                    "type": "number"
+                },
+                "switches": {
+                    "description": "上下文切换次数",
+                    "type": "integer"
+                },
+                "ticks": {
+                    "description": "该处理机存在的时钟周期数",
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "utilization": {
+                    "description": "忙碌的时间占比",
+                    "type": "number"
+                }
+            }
+        },
+        "models.RealTimeTask": {
+            "type": "object",
+            "properties": {
+                "deadline": {
+                    "description": "相对截止时间，不超过周期，0表示等于周期",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "jobs": {
+                    "description": "已释放的作业数",
+                    "type": "integer"
+                },
+                "memorySize": {
+                    "description": "每个作业的内存大小",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nextRelease": {
+                    "description": "周期任务下一个作业的释放时刻；偶发任务最早可以再次释放的时刻",
+                    "type": "integer"
+                },
+                "period": {
+                    "description": "周期；偶发任务为相邻两次释放的最小间隔",
+                    "type": "integer"
+                },
+                "phase": {
+                    "description": "第一个作业相对任务添加时刻的释放时间",
+                    "type": "integer"
+                },
+                "sporadic": {
+                    "description": "偶发任务只在请求释放时产生作业",
+                    "type": "boolean"
+                },
+                "wcet": {
+                    "description": "最坏情况执行时间，即每个作业的运行时间",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SchedulabilityReport": {
+            "type": "object",
+            "properties": {
+                "density": {
+                    "description": "各任务 WCET/截止时间 之和",
+                    "type": "number"
+                },
+                "edfSchedulable": {
+                    "description": "截止时间等于周期时利用率不超过1；否则密度不超过1（充分条件）",
+                    "type": "boolean"
+                },
+                "implicitDeadlines": {
+                    "description": "所有任务的截止时间都等于周期",
+                    "type": "boolean"
+                },
+                "liuLaylandBound": {
+                    "description": "n(2^(1/n)-1)",
+                    "type": "number"
+                },
+                "rmBoundTest": {
+                    "description": "截止时间等于周期且利用率不超过Liu-Layland界，不满足时仍可能可调度",
+                    "type": "boolean"
+                },
+                "rmSchedulable": {
+                    "description": "响应时间分析：所有任务在速率单调调度下都满足截止时间",
+                    "type": "boolean"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskAnalysis"
+                    }
+                },
+                "utilization": {
+                    "description": "总利用率",
+                    "type": "number"
+                }
+            }
+        },
+        "models.SchedulingPolicy": {
+            "type": "string",
+            "enum": [
+                "priority",
+                "fcfs",
+                "sjf",
+                "rr",
+                "rm",
+                "edf",
+                "lottery",
+                "stride",
+                "cfs",
+                "fairshare"
+            ],
+            "x-enum-comments": {
+                "PolicyCFS": "完全公平调度，虚拟运行时间最小的进程优先",
+                "PolicyEDF": "最早截止时间优先",
+                "PolicyFCFS": "先来先服务，非抢占",
+                "PolicyFairShare": "公平份额调度，先在用户组之间按份额分配，再在组内按彩票数分配",
+                "PolicyLottery": "彩票调度，按彩票数随机抽取",
+                "PolicyPriority": "动态优先数，每个时间片后重新调度",
+                "PolicyRM": "速率单调，周期越短的实时作业优先级越高",
+                "PolicyRR": "时间片轮转",
+                "PolicySJF": "最短剩余时间优先，抢占式",
+                "PolicyStride": "步长调度，按彩票数确定性地分配"
+            },
+            "x-enum-varnames": [
+                "PolicyPriority",
+                "PolicyFCFS",
+                "PolicySJF",
+                "PolicyRR",
+                "PolicyRM",
+                "PolicyEDF",
+                "PolicyLottery",
+                "PolicyStride",
+                "PolicyCFS",
+                "PolicyFairShare"
+            ]
+        },
+        "models.SessionInfo": {
+            "type": "object",
+            "properties": {
+                "clock": {
+                    "type": "integer"
+                },
+                "config": {
+                    "$ref": "#/definitions/models.SystemConfig"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "processes": {
+                    "description": "会话中的进程总数，包括已完成的进程",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ShareReport": {
+            "type": "object",
+            "properties": {
+                "clock": {
+                    "type": "integer"
+                },
+                "processes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProcessShare"
+                    }
+                }
+            }
+        },
+        "models.Snapshot": {
+            "type": "object",
+            "properties": {
+                "ageBackup": {
+                    "type": "boolean"
+                },
+                "agingInterval": {
+                    "type": "integer"
+                },
+                "agingStep": {
+                    "type": "integer"
+                },
+                "balanceInterval": {
+                    "type": "integer"
+                },
+                "clock": {
+                    "description": "已执行的时钟周期数",
+                    "type": "integer"
+                },
+                "contextSwitchCost": {
+                    "type": "integer"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimelineEvent"
+                    }
+                },
+                "groupShares": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "lockProtocol": {
+                    "$ref": "#/definitions/models.LockProtocol"
+                },
+                "maxProcesses": {
+                    "type": "integer"
+                },
+                "memory": {
+                    "$ref": "#/definitions/models.MemoryManager"
+                },
+                "migrationCost": {
+                    "type": "integer"
+                },
+                "minGranularity": {
+                    "type": "integer"
+                },
+                "minVruntime": {
+                    "description": "可运行进程的最小虚拟运行时间，单调不减",
+                    "type": "integer"
+                },
+                "nextPid": {
+                    "description": "下一个分配的PID",
+                    "type": "integer"
+                },
+                "nextTaskId": {
+                    "type": "integer"
+                },
+                "perProcessorQueues": {
+                    "type": "boolean"
+                },
+                "policy": {
+                    "$ref": "#/definitions/models.SchedulingPolicy"
+                },
+                "processorCount": {
+                    "type": "integer"
+                },
+                "processors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProcessorSpec"
+                    }
+                },
+                "queue": {
+                    "$ref": "#/definitions/models.ProcessQueue"
+                },
+                "randomState": {
+                    "description": "随机数生成器的当前状态",
+                    "type": "integer"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "started": {
+                    "description": "是否已做出过调度决策",
+                    "type": "boolean"
+                },
+                "targetLatency": {
+                    "type": "integer"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RealTimeTask"
+                    }
+                },
+                "timeQuantum": {
+                    "type": "integer"
+                },
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TimelineSlot"
+                    }
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "main.StatusResponse": {
+        "models.StateChange": {
             "type": "object",
             "properties": {
-                "memory": {
-                    "$ref": "#/definitions/models.MemoryManager"
+                "from": {
+                    "description": "为空表示刚创建的进程",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ProcessState"
+                        }
+                    ]
                 },
-                "queue": {
-                    "$ref": "#/definitions/models.ProcessQueue"
+                "reason": {
+                    "type": "string"
+                },
+                "tick": {
+                    "description": "新状态从该时钟周期开始生效",
+                    "type": "integer"
+                },
+                "to": {
+                    "$ref": "#/definitions/models.ProcessState"
                 }
             }
         },
-        "models.MemoryBlock": {
+        "models.Stats": {
             "type": "object",
             "properties": {
-                "isUsed": {
-                    "type": "boolean"
+                "avgResponse": {
+                    "type": "number"
                 },
-                "length": {
+                "avgTurnaround": {
+                    "type": "number"
+                },
+                "avgWaiting": {
+                    "type": "number"
+                },
+                "clock": {
                     "type": "integer"
                 },
-                "start": {
+                "contextSwitches": {
+                    "description": "所有处理机的上下文切换次数之和",
+                    "type": "integer"
+                },
+                "cpuUtilization": {
+                    "description": "处理机忙碌的时间占比",
+                    "type": "number"
+                },
+                "deadlineMisses": {
+                    "description": "错过截止时刻的实时作业数",
+                    "type": "integer"
+                },
+                "effectiveUtilization": {
+                    "description": "扣除上下文切换开销后的处理机利用率",
+                    "type": "number"
+                },
+                "finished": {
+                    "description": "已完成进程数",
+                    "type": "integer"
+                },
+                "gangs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GangStats"
+                    }
+                },
+                "groups": {
+                    "description": "各用户组的处理机使用情况，按组名排序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GroupStats"
+                    }
+                },
+                "maxWait": {
+                    "description": "所有进程中最长的一次连续等待",
+                    "type": "integer"
+                },
+                "migrations": {
+                    "description": "所有进程的迁移次数之和",
+                    "type": "integer"
+                },
+                "overheadTicks": {
+                    "description": "所有处理机用于上下文切换的时钟周期数",
+                    "type": "integer"
+                },
+                "perProcess": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProcessStats"
+                    }
+                },
+                "perProcessor": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProcessorStats"
+                    }
+                },
+                "processes": {
+                    "description": "进程总数",
                     "type": "integer"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaskStats"
+                    }
+                },
+                "throughput": {
+                    "description": "每个时钟周期完成的进程数",
+                    "type": "number"
+                },
+                "users": {
+                    "description": "各用户的处理机使用情况，按用户名排序",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UserStats"
+                    }
                 }
             }
         },
-        "models.MemoryManager": {
+        "models.SystemConfig": {
             "type": "object",
             "properties": {
-                "blocks": {
+                "ageBackup": {
+                    "description": "后备队列中的进程也参与老化，并按优先数调入",
+                    "type": "boolean"
+                },
+                "agingInterval": {
+                    "description": "动态优先数策略下进程每连续等待多少个时钟周期提高一次优先数，0表示不老化",
+                    "type": "integer"
+                },
+                "agingStep": {
+                    "description": "每次老化增加的优先数",
+                    "type": "integer"
+                },
+                "allocator": {
+                    "description": "内存分配算法",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AllocationAlgorithm"
+                        }
+                    ]
+                },
+                "balanceInterval": {
+                    "description": "独立就绪队列之间负载均衡的间隔，0表示只在处理机空闲时拉取",
+                    "type": "integer"
+                },
+                "contextSwitchCost": {
+                    "description": "处理机换一个进程运行时上下文切换占用的时钟周期数",
+                    "type": "integer"
+                },
+                "groupShares": {
+                    "description": "公平份额调度中各用户组的份额，未列出的组为默认值1",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "lockProtocol": {
+                    "description": "锁协议：none、inheritance、ceiling",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LockProtocol"
+                        }
+                    ]
+                },
+                "maxProcesses": {
+                    "description": "道数",
+                    "type": "integer"
+                },
+                "migrationCost": {
+                    "description": "进程换到另一个处理机时额外占用的时钟周期数",
+                    "type": "integer"
+                },
+                "minGranularity": {
+                    "description": "完全公平调度中每次占用处理机的最短时间",
+                    "type": "integer"
+                },
+                "osMemory": {
+                    "description": "操作系统占用的内存大小",
+                    "type": "integer"
+                },
+                "perProcessorQueues": {
+                    "description": "每个处理机使用独立的就绪队列",
+                    "type": "boolean"
+                },
+                "policy": {
+                    "description": "调度策略",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SchedulingPolicy"
+                        }
+                    ]
+                },
+                "processorCount": {
+                    "description": "处理机数量",
+                    "type": "integer"
+                },
+                "processors": {
+                    "description": "各处理机的规格，缺少的处理机速度为1",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.MemoryBlock"
+                        "$ref": "#/definitions/models.ProcessorSpec"
                     }
                 },
-                "osSize": {
+                "seed": {
+                    "description": "彩票调度使用的随机数种子",
                     "type": "integer"
                 },
-                "totalSize": {
+                "targetLatency": {
+                    "description": "完全公平调度中每个可运行进程至少运行一次的周期",
+                    "type": "integer"
+                },
+                "timeQuantum": {
+                    "description": "时间片轮转的时间片长度",
+                    "type": "integer"
+                },
+                "totalMemory": {
+                    "description": "内存总大小",
                     "type": "integer"
                 }
             }
         },
-        "models.PCB": {
+        "models.TaskAnalysis": {
             "type": "object",
             "properties": {
-                "memorySize": {
+                "deadline": {
                     "type": "integer"
                 },
-                "memoryStart": {
+                "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "pid": {
+                "period": {
                     "type": "integer"
                 },
-                "predecessors": {
-                    "description": "前驱进程PID列表",
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "responseTime": {
+                    "description": "速率单调优先级下的最坏响应时间，超过截止时间时为迭代停止时的值",
+                    "type": "integer"
                 },
-                "priority": {
+                "schedulable": {
+                    "description": "最坏响应时间不超过截止时间",
+                    "type": "boolean"
+                },
+                "utilization": {
+                    "description": "WCET/周期",
+                    "type": "number"
+                },
+                "wcet": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TaskStats": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "description": "已完成的作业数",
                     "type": "integer"
                 },
-                "processorId": {
-                    "description": "-1表示未分配处理机",
+                "maxResponse": {
+                    "description": "已完成作业从释放到完成的最长时间",
                     "type": "integer"
                 },
-                "requiredTime": {
-                    "description": "剩余运行时间",
+                "missed": {
+                    "description": "错过截止时刻的作业数",
                     "type": "integer"
                 },
-                "state": {
-                    "$ref": "#/definitions/models.ProcessState"
+                "name": {
+                    "type": "string"
                 },
-                "successors": {
-                    "description": "后继进程PID列表",
+                "released": {
+                    "description": "已释放的作业数",
+                    "type": "integer"
+                },
+                "taskId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TimelineEvent": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "pids": {
+                    "description": "受影响的进程",
                     "type": "array",
                     "items": {
                         "type": "integer"
                     }
                 },
-                "totalTime": {
-                    "description": "总运行时间",
+                "tick": {
                     "type": "integer"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "models.ProcessQueue": {
+        "models.TimelineSlot": {
             "type": "object",
             "properties": {
-                "backup": {
+                "processors": {
+                    "description": "下标为处理机编号，值为PID，-1表示空闲",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.PCB"
+                        "type": "integer"
                     }
                 },
-                "ready": {
+                "switching": {
+                    "description": "下标为处理机编号，为true表示该处理机在做上下文切换",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.PCB"
+                        "type": "boolean"
                     }
                 },
-                "running": {
+                "tick": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.UserStats": {
+            "type": "object",
+            "properties": {
+                "cpuTime": {
+                    "type": "integer"
+                },
+                "finished": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "processes": {
+                    "type": "integer"
+                },
+                "usage": {
+                    "description": "占所有进程处理机时间的比例",
+                    "type": "number"
+                }
+            }
+        },
+        "models.Workload": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "processes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.PCB"
+                        "$ref": "#/definitions/models.WorkloadProcess"
                     }
+                }
+            }
+        },
+        "models.WorkloadProcess": {
+            "type": "object",
+            "properties": {
+                "affinity": {
+                    "description": "允许运行的处理机位掩码，0表示不限制",
+                    "type": "integer"
                 },
-                "suspended": {
+                "arrival": {
+                    "description": "相对负载提交时刻的到达时间",
+                    "type": "integer"
+                },
+                "burst": {
+                    "description": "运行时间",
+                    "type": "integer"
+                },
+                "criticalSections": {
+                    "description": "临界区",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.PCB"
+                        "$ref": "#/definitions/models.CriticalSection"
                     }
                 },
-                "waiting": {
+                "gang": {
+                    "description": "所属进程组，组内进程同时运行",
+                    "type": "string"
+                },
+                "group": {
+                    "description": "所属用户组，为空表示默认组",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "负载内ID，为空时使用进程名",
+                    "type": "string"
+                },
+                "memory": {
+                    "description": "内存大小",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "predecessors": {
+                    "description": "前驱进程的负载内ID",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.PCB"
+                        "type": "string"
                     }
+                },
+                "priority": {
+                    "description": "优先数",
+                    "type": "integer"
+                },
+                "tickets": {
+                    "description": "比例份额调度的彩票数，0表示默认值",
+                    "type": "integer"
+                },
+                "user": {
+                    "description": "所属用户，为空表示默认用户",
+                    "type": "string"
                 }
             }
         },
-        "models.ProcessState": {
-            "type": "string",
-            "enum": [
-                "ready",
-                "running",
-                "waiting",
-                "finished",
-                "suspended"
-            ],
-            "x-enum-varnames": [
-                "Ready",
-                "Running",
-                "Waiting",
-                "Finished",
-                "Suspended"
-            ]
+        "services.PrecedenceError": {
+            "type": "object",
+            "properties": {
+                "cycle": {
+                    "description": "环上的进程PID，首尾相同",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "localCycle": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "localId": {
+                    "description": "批量提交时对应的批内ID",
+                    "type": "string"
+                },
+                "localPredecessor": {
+                    "type": "string"
+                },
+                "pid": {
+                    "description": "出错的进程PID",
+                    "type": "integer"
+                },
+                "predecessor": {
+                    "description": "出错的前驱PID",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "失败原因",
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -415,7 +3413,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "操作系统调度器 API",
-	Description:      "这是一个操作系统调度实验的后端API服务",
+	Description:      "这是一个操作系统调度实验的后端API服务\n不带前缀的接口作用于默认会话；在接口路径前加上 /sessions/{id} 即作用于指定会话，例如 POST /sessions/{id}/schedule",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
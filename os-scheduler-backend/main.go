@@ -1,8 +1,14 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"os-scheduler-backend/models"
 	"os-scheduler-backend/services"
 
@@ -26,25 +32,154 @@ type StatusResponse struct {
 	Memory *models.MemoryManager `json:"memory"`
 }
 
+// statusResponse 在调度器的锁内复制进程队列和内存布局
+func statusResponse(scheduler *services.Scheduler) StatusResponse {
+	state := scheduler.State()
+	return StatusResponse{
+		Queue:  state.Queue,
+		Memory: state.Memory,
+	}
+}
+
 // ProcessorStatusResponse 表示处理机状态响应
 type ProcessorStatusResponse struct {
-	Processors []*models.PCB `json:"processors"` // 每个处理机当前运行的进程，如果没有则为nil
+	Processors []*models.PCB           `json:"processors"` // 每个处理机当前运行的进程，如果没有则为nil
+	Specs      []models.ProcessorSpec `json:"specs"`      // 每个处理机的速度和类型
+}
+
+// BatchProcessRequest 批量提交进程的请求体
+type BatchProcessRequest struct {
+	Processes []models.BatchProcess `json:"processes"`
+}
+
+// BatchProcessResponse 批量提交进程的响应
+type BatchProcessResponse struct {
+	Mapping   map[string]int `json:"mapping"`   // 批内ID到PID的映射
+	Processes []*models.PCB  `json:"processes"` // 创建的进程，顺序与请求一致
+}
+
+// RestoreRequest 恢复快照的请求体
+type RestoreRequest struct {
+	Snapshot *models.Snapshot `json:"snapshot"` // 要恢复的快照，为空时从快照文件恢复
+}
+
+// RewindResponse 回退调度的响应
+type RewindResponse struct {
+	Steps     int                  `json:"steps"`     // 实际回退的步数
+	Clock     int                  `json:"clock"`     // 回退后的时钟
+	Remaining int                  `json:"remaining"` // 还可以继续回退的步数
+	Queue     *models.ProcessQueue `json:"queue"`
+}
+
+// CompareRequest 对比调度策略的请求体
+type CompareRequest struct {
+	Workload   models.Workload              `json:"workload"`
+	Policies   []models.SchedulingPolicy    `json:"policies"`   // 为空时使用当前调度策略
+	Allocators []models.AllocationAlgorithm `json:"allocators"` // 为空时使用当前内存分配算法
+	Config     *models.SystemConfig         `json:"config"`     // 为空时使用当前系统参数
+	MaxTicks   int                          `json:"maxTicks"`   // 为0时使用上限 services.MaxCompareTicks
+}
+
+// WorkloadSubmitResponse 提交负载的响应
+type WorkloadSubmitResponse struct {
+	Workload *models.Workload `json:"workload"`
+	Mapping  map[string]int   `json:"mapping"` // 负载内ID到PID的映射
+}
+
+// 设置亲和性请求
+type AffinityRequest struct {
+	Mask uint64 `json:"mask"` // 第i位为1表示允许在处理机i上运行，0表示不限制
+}
+
+// 设置彩票数请求
+type TicketsRequest struct {
+	Tickets int `json:"tickets"` // 0表示默认值，最多 services.MaxTickets 张
+}
+
+// 声明进程组请求
+type GangRequest struct {
+	Name string `json:"name"`
+	PIDs []int  `json:"pids"`
+}
+
+// 释放偶发作业的响应
+type JobReleaseResponse struct {
+	PID int `json:"pid"` // 作业的PID
+}
+
+// 修改处理机数量请求
+type ProcessorCountRequest struct {
+	Count int `json:"count"`
+}
+
+// 创建会话请求
+type SessionCreateRequest struct {
+	ID     string               `json:"id"`     // 为空时自动生成
+	Config *models.SystemConfig `json:"config"` // 为空时使用默认系统参数
 }
 
 // @title 操作系统调度器 API
 // @version 1.0
 // @description 这是一个操作系统调度实验的后端API服务
+// @description 不带前缀的接口作用于默认会话；在接口路径前加上 /sessions/{id} 即作用于指定会话，例如 POST /sessions/{id}/schedule
 // @host localhost:8080
 // @BasePath /
 var (
-	scheduler     *services.Scheduler
-	memoryManager *services.MemoryManager
+	sessions     *services.SessionManager
+	systemConfig models.SystemConfig // 启动时的系统参数，新建会话默认使用
+	snapshotFile string
 )
 
 func main() {
-	// 初始化调度器和内存管理器
-	memoryManager = services.NewMemoryManager(4096, 256)   // 总内存4096，操作系统占256
-	scheduler = services.NewScheduler(2, 8, memoryManager) // 传入内存管理器
+	flag.StringVar(&snapshotFile, "snapshot-file", "scheduler-snapshot.json", "快照文件路径")
+	autosaveEvery := flag.Int("autosave", 0, "每执行N次调度自动保存一次快照，0表示不自动保存")
+	restoreOnStart := flag.Bool("restore", false, "启动时从快照文件恢复状态")
+	historySize := flag.Int("history", services.DefaultHistorySize, "可回退的最大调度步数")
+	eventLogFile := flag.String("event-log", "", "将改变状态的调用追加记录到该JSONL文件，为空时不记录")
+	replayFile := flag.String("replay", "", "启动时重放该事件日志文件以重建状态")
+	maxSessions := flag.Int("max-sessions", services.DefaultMaxSessions, "允许同时存在的最大会话数")
+	configFlags := services.BindConfigFlags(flag.CommandLine)
+	flag.Parse()
+
+	// 系统参数依次来自默认值、配置文件、环境变量和命令行参数
+	var err error
+	systemConfig, err = configFlags.Load()
+	if err != nil {
+		log.Fatalf("读取系统参数失败: %v", err)
+	}
+
+	// 初始化默认会话，不带会话前缀的接口都作用于默认会话
+	sessions = services.NewSessionManager(*maxSessions)
+	session, err := sessions.Create(services.DefaultSessionID, systemConfig)
+	if err != nil {
+		log.Fatalf("创建默认会话失败: %v", err)
+	}
+	scheduler := session.Scheduler
+	scheduler.EnableAutosave(snapshotFile, *autosaveEvery)
+	scheduler.SetHistorySize(*historySize)
+	if *restoreOnStart {
+		if err := scheduler.LoadSnapshot(snapshotFile); err != nil {
+			log.Fatalf("从快照 %s 恢复失败: %v", snapshotFile, err)
+		}
+	}
+	if *replayFile != "" {
+		f, err := os.Open(*replayFile)
+		if err != nil {
+			log.Fatalf("打开事件日志 %s 失败: %v", *replayFile, err)
+		}
+		if err := scheduler.Replay(f); err != nil {
+			log.Fatalf("重放事件日志 %s 失败: %v", *replayFile, err)
+		}
+		f.Close()
+	}
+	if *eventLogFile != "" {
+		eventLog, err := services.OpenEventLog(*eventLogFile)
+		if err != nil {
+			log.Fatalf("打开事件日志 %s 失败: %v", *eventLogFile, err)
+		}
+		defer eventLog.Close()
+		scheduler.SetEventLog(eventLog)
+	}
 
 	r := gin.Default()
 
@@ -60,30 +195,114 @@ func main() {
 		c.Next()
 	})
 
-	// API路由
+	// API路由：不带前缀的接口作用于默认会话，/sessions/:id 前缀下的同名接口作用于指定会话
+	registerSystemRoutes(r.Group("/", useDefaultSession))
+	registerSystemRoutes(r.Group("/sessions/:id", useSession))
+
+	// 会话管理
+	r.POST("/sessions", createSession)
+	r.GET("/sessions", listSessions)
+	r.GET("/sessions/:id", getSession)
+	r.DELETE("/sessions/:id", deleteSession)
+
+	// 添加 swagger 路由
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	r.Run(":8080")
+}
+
+// registerSystemRoutes 注册作用于单个会话的接口
+func registerSystemRoutes(r gin.IRoutes) {
 	r.POST("/process", addProcess)
+	r.POST("/processes/batch", addProcessBatch)
 	r.GET("/status", getStatus)
 	r.POST("/schedule", runSchedule)
 	r.POST("/suspend/:pid", suspendProcess)
 	r.POST("/resume/:pid", resumeProcess)
+	r.PUT("/process/:pid/affinity", setAffinity)
+	r.PUT("/process/:pid/tickets", setTickets)
+	r.GET("/process/:pid/history", getProcessHistory)
+	r.GET("/shares", getShares)
+	r.POST("/gangs", declareGang)
+	r.GET("/gangs", listGangs)
+	r.GET("/locks", listLocks)
+	r.POST("/scenarios/pathfinder", loadPathfinder)
+	r.POST("/rt/tasks", addTask)
+	r.GET("/rt/tasks", listTasks)
+	r.DELETE("/rt/tasks/:id", removeTask)
+	r.POST("/rt/tasks/:id/release", releaseJob)
+	r.GET("/rt/analysis", analyzeTasks)
 	r.GET("/processor-status", getProcessorStatus)
+	r.GET("/graph", getGraph)
+	r.GET("/stats", getStats)
+	r.GET("/timeline", getTimeline)
+	r.GET("/timeline/events", getTimelineEvents)
+	r.PUT("/processors", setProcessorCount)
+	r.POST("/compare", comparePolicies)
+	r.POST("/workload/generate", generateWorkload)
+	r.POST("/workload/import", importWorkload)
+	r.GET("/workload/export", exportWorkload)
 	r.POST("/reset", resetSystem) // 添加重置系统的路由
+	r.POST("/snapshot", saveSnapshot)
+	r.POST("/restore", restoreSnapshot)
+	r.POST("/rewind", rewindSchedule)
+	r.POST("/replay", replayEventLog)
+	r.GET("/config", getConfig)
+	r.PUT("/config", updateConfig)
+}
 
-	// 添加 swagger 路由
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+// useDefaultSession 让后续处理函数作用于默认会话
+func useDefaultSession(c *gin.Context) {
+	session, _ := sessions.Get(services.DefaultSessionID)
+	c.Set("session", session)
+	c.Next()
+}
 
-	r.Run(":8080")
+// useSession 按路径中的会话ID查找会话，找不到时返回404
+func useSession(c *gin.Context) {
+	session, ok := sessions.Get(c.Param("id"))
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusNotFound, Response{
+			Code:    404,
+			Message: fmt.Sprintf("找不到会话 %s", c.Param("id")),
+		})
+		return
+	}
+	c.Set("session", session)
+	c.Next()
+}
+
+// currentSession 返回当前请求作用的会话
+func currentSession(c *gin.Context) *services.Session {
+	return c.MustGet("session").(*services.Session)
+}
+
+// currentScheduler 返回当前请求作用的会话的调度器
+func currentScheduler(c *gin.Context) *services.Scheduler {
+	return currentSession(c).Scheduler
+}
+
+// sessionSnapshotFile 返回会话的快照文件路径，默认会话使用 -snapshot-file 指定的文件，
+// 其他会话在文件名后加上会话ID
+func sessionSnapshotFile(c *gin.Context) string {
+	id := currentSession(c).ID
+	if id == services.DefaultSessionID {
+		return snapshotFile
+	}
+	ext := filepath.Ext(snapshotFile)
+	return strings.TrimSuffix(snapshotFile, ext) + "-" + id + ext
 }
 
 // @Summary 添加新进程
-// @Description 添加一个新的进程到系统中
+// @Description 添加一个新的进程到系统中。前驱进程必须已存在，不能以自身为前驱，也不能构成环；已完成的前驱视为已满足
 // @Accept json
 // @Produce json
 // @Param process body models.PCB true "进程信息"
 // @Success 200 {object} models.PCB
-// @Failure 400 {object} Response
+// @Failure 400 {object} Response{data=services.PrecedenceError} "前驱关系无效或内存分配失败"
 // @Router /process [post]
 func addProcess(c *gin.Context) {
+	scheduler := currentScheduler(c)
 	var process models.PCB
 	if err := c.BindJSON(&process); err != nil {
 		c.JSON(http.StatusBadRequest, Response{
@@ -94,23 +313,110 @@ func addProcess(c *gin.Context) {
 		return
 	}
 
-	start, err := memoryManager.Allocate(process.MemorySize)
+	if err := scheduler.AddProcess(&process); err != nil {
+		var data interface{} = err.Error()
+		var precedenceErr *services.PrecedenceError
+		if errors.As(err, &precedenceErr) {
+			data = precedenceErr
+		}
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+			Data:    data,
+		})
+		return
+	}
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "进程添加成功",
+		Data:    process,
+	})
+}
+
+// @Summary 批量添加进程
+// @Description 一次提交整个任务依赖图。进程通过批内ID互相引用前驱，也可以通过predecessorPids引用系统中已存在的进程。所有进程的内存要么全部分配成功，要么全部不分配
+// @Accept json
+// @Produce json
+// @Param batch body BatchProcessRequest true "批量进程信息"
+// @Success 200 {object} Response{data=BatchProcessResponse} "批量添加成功"
+// @Failure 400 {object} Response "前驱关系无效或内存分配失败"
+// @Router /processes/batch [post]
+func addProcessBatch(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var request BatchProcessRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	mapping, processes, err := scheduler.AddProcessBatch(request.Processes)
+	if err != nil {
+		var data interface{} = err.Error()
+		var precedenceErr *services.PrecedenceError
+		if errors.As(err, &precedenceErr) {
+			data = precedenceErr
+		}
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: err.Error(),
+			Data:    data,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "批量添加进程成功",
+		Data: BatchProcessResponse{
+			Mapping:   mapping,
+			Processes: processes,
+		},
+	})
+}
+
+// @Summary 回退调度
+// @Description 回退指定次数的调度，恢复到对应调度执行前的进程队列和内存布局。历史记录不足时回退到最早的记录
+// @Produce json
+// @Param steps query int false "回退步数，默认为1"
+// @Success 200 {object} Response{data=RewindResponse} "回退成功"
+// @Failure 400 {object} Response "回退失败"
+// @Router /rewind [post]
+func rewindSchedule(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var steps int
+	if _, err := fmt.Sscanf(c.DefaultQuery("steps", "1"), "%d", &steps); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的回退步数",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	rewound, err := scheduler.Rewind(steps)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, Response{
 			Code:    400,
-			Message: "内存分配失败",
+			Message: "回退失败",
 			Data:    err.Error(),
 		})
 		return
 	}
-	process.MemoryStart = start
-	process.TotalRequiredTime = process.RequiredTime
+	state := scheduler.State()
 
-	scheduler.AddProcess(&process)
 	c.JSON(http.StatusOK, Response{
 		Code:    0,
-		Message: "进程添加成功",
-		Data:    process,
+		Message: fmt.Sprintf("已回退 %d 步", rewound),
+		Data: RewindResponse{
+			Steps:     rewound,
+			Clock:     state.Clock,
+			Remaining: scheduler.HistoryLength(),
+			Queue:     state.Queue,
+		},
 	})
 }
 
@@ -120,10 +426,7 @@ func addProcess(c *gin.Context) {
 // @Success 200 {object} Response{data=StatusResponse} "获取状态成功"
 // @Router /status [get]
 func getStatus(c *gin.Context) {
-	status := StatusResponse{
-		Queue:  scheduler.Queue,
-		Memory: memoryManager.Memory,
-	}
+	status := statusResponse(currentScheduler(c))
 	c.JSON(http.StatusOK, Response{
 		Code:    0,
 		Message: "获取状态成功",
@@ -137,22 +440,24 @@ func getStatus(c *gin.Context) {
 // @Success 200 {object} Response{data=models.ProcessQueue} "调度执行成功"
 // @Router /schedule [post]
 func runSchedule(c *gin.Context) {
+	scheduler := currentScheduler(c)
 	scheduler.Schedule()
 	c.JSON(http.StatusOK, Response{
 		Code:    0,
 		Message: "调度执行成功",
-		Data:    scheduler.Queue,
+		Data:    scheduler.State().Queue,
 	})
 }
 
 // @Summary 挂起进程
-// @Description 将指定进程挂起，暂停其执行
+// @Description 将就绪、运行、后备或等待队列中的进程挂起，暂停其执行。等待中的进程转为挂起阻塞，其余转为挂起就绪
 // @Produce json
 // @Param pid path int true "进程ID"
 // @Success 200 {object} Response "进程挂起成功"
 // @Failure 400 {object} Response "进程挂起失败"
 // @Router /suspend/{pid} [post]
 func suspendProcess(c *gin.Context) {
+	scheduler := currentScheduler(c)
 	pid := c.Param("pid")
 	if pid == "" {
 		c.JSON(http.StatusBadRequest, Response{
@@ -188,13 +493,14 @@ func suspendProcess(c *gin.Context) {
 }
 
 // @Summary 恢复进程
-// @Description 恢复已挂起的进程，使其重新参与调度
+// @Description 恢复已挂起的进程，使其重新参与调度：挂起就绪的进程回到就绪队列（道数已满时进入后备队列），挂起阻塞的进程回到等待队列
 // @Produce json
 // @Param pid path int true "进程ID"
 // @Success 200 {object} Response "进程恢复成功"
 // @Failure 400 {object} Response "进程恢复失败"
 // @Router /resume/{pid} [post]
 func resumeProcess(c *gin.Context) {
+	scheduler := currentScheduler(c)
 	pid := c.Param("pid")
 	if pid == "" {
 		c.JSON(http.StatusBadRequest, Response{
@@ -229,46 +535,983 @@ func resumeProcess(c *gin.Context) {
 	})
 }
 
-// @Summary 获取处理机状态
-// @Description 获取所有处理机的当前运行状态，包括每个处理机上正在运行的进程信息
+// @Summary 设置进程亲和性
+// @Description 设置进程允许运行的处理机，掩码第i位为1表示允许在处理机i上运行，0表示不限制。正在运行的进程若不再允许在当前处理机上运行，下一次调度时让出处理机
+// @Accept json
 // @Produce json
-// @Success 200 {object} Response{data=ProcessorStatusResponse} "获取处理机状态成功"
-// @Router /processor-status [get]
-func getProcessorStatus(c *gin.Context) {
-	processorCount := scheduler.ProcessorCount
-	processors := make([]*models.PCB, processorCount)
+// @Param pid path int true "进程ID"
+// @Param request body AffinityRequest true "亲和性掩码"
+// @Success 200 {object} Response "设置成功"
+// @Failure 400 {object} Response "设置失败"
+// @Router /process/{pid}/affinity [put]
+func setAffinity(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var processID int
+	if _, err := fmt.Sscanf(c.Param("pid"), "%d", &processID); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的进程ID",
+			Data:    err.Error(),
+		})
+		return
+	}
 
-	for _, p := range scheduler.Queue.Running {
-		if p.ProcessorID >= 0 && p.ProcessorID < processorCount {
-			processors[p.ProcessorID] = p
-		}
+	var request AffinityRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的请求参数",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	if err := scheduler.SetAffinity(processID, request.Mask); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "设置亲和性失败",
+			Data:    err.Error(),
+		})
+		return
 	}
 
 	c.JSON(http.StatusOK, Response{
 		Code:    0,
-		Message: "获取处理机状态成功",
-		Data: ProcessorStatusResponse{
-			Processors: processors,
-		},
+		Message: fmt.Sprintf("进程 %d 的亲和性已设置", processID),
 	})
 }
 
-// @Summary 重置系统
-// @Description 强制重启整个系统，清空所有进程和内存。这将终止所有正在运行的进程，释放所有内存分配，并将系统恢复到初始状态。系统参数（如处理机数量、最大进程数）将保持不变。
-// @Tags system
+// @Summary 获取进程状态历史
+// @Description 按时间先后返回进程的每一次状态转换：生效的时钟周期、原状态（为空表示刚创建）、新状态和原因
+// @Produce json
+// @Param pid path int true "进程ID"
+// @Success 200 {object} Response{data=[]models.StateChange} "获取状态历史成功"
+// @Failure 400 {object} Response "无效的进程ID"
+// @Failure 404 {object} Response "找不到进程"
+// @Router /process/{pid}/history [get]
+func getProcessHistory(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var processID int
+	if _, err := fmt.Sscanf(c.Param("pid"), "%d", &processID); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的进程ID",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	history, err := scheduler.ProcessHistory(processID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, Response{
+			Code:    404,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取状态历史成功",
+		Data:    history,
+	})
+}
+
+// @Summary 设置进程彩票数
+// @Description 设置进程在彩票调度和步长调度中持有的彩票数，0表示默认值100，从下一次调度开始生效
 // @Accept json
 // @Produce json
-// @Success 200 {object} Response "系统重置成功"
-// @Failure 500 {object} Response "系统重置失败"
-// @Router /reset [post]
-func resetSystem(c *gin.Context) {
-	// 重新初始化调度器和内存管理器
-	memoryManager = services.NewMemoryManager(4096, 256)   // 保持与 main 函数中相同的参数
-	scheduler = services.NewScheduler(scheduler.ProcessorCount, scheduler.MaxProcesses, memoryManager)
+// @Param pid path int true "进程ID"
+// @Param request body TicketsRequest true "彩票数"
+// @Success 200 {object} Response "设置成功"
+// @Failure 400 {object} Response "设置失败"
+// @Router /process/{pid}/tickets [put]
+func setTickets(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var processID int
+	if _, err := fmt.Sscanf(c.Param("pid"), "%d", &processID); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的进程ID",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	var request TicketsRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的请求参数",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	if err := scheduler.SetTickets(processID, request.Tickets); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "设置彩票数失败",
+			Data:    err.Error(),
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, Response{
 		Code:    0,
-		Message: "系统已重置",
-		Data:    nil,
+		Message: fmt.Sprintf("进程 %d 的彩票数已设置", processID),
+	})
+}
+
+// @Summary 获取比例份额
+// @Description 比较各进程实际占用的处理机时间与按彩票数应得的处理机时间。每个时钟周期的处理机时间按彩票数分给当时就绪和运行中的进程，每个进程最多得到一个处理机
+// @Produce json
+// @Success 200 {object} Response{data=models.ShareReport} "获取比例份额成功"
+// @Router /shares [get]
+func getShares(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取比例份额成功",
+		Data:    scheduler.Shares(),
+	})
+}
+
+// @Summary 声明进程组
+// @Description 把一组进程声明为进程组，组内进程只在同一个时钟周期内同时占用不同的处理机时才运行，组内任一进程让出处理机时整组让出。同名的进程组已存在时替换其成员，组内进程数不能超过处理机数量和道数，组内进程之间不能有前驱关系，各进程的亲和性要允许它们同时在不同的处理机上运行
+// @Accept json
+// @Produce json
+// @Param request body GangRequest true "进程组名称和成员"
+// @Success 200 {object} Response "声明成功"
+// @Failure 400 {object} Response "声明失败"
+// @Router /gangs [post]
+func declareGang(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var request GangRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的请求参数",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	if err := scheduler.DeclareGang(request.Name, request.PIDs); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "声明进程组失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: fmt.Sprintf("进程组 %s 已声明", request.Name),
+	})
+}
+
+// @Summary 获取进程组
+// @Description 获取所有进程组及其成员，按名称排序
+// @Produce json
+// @Success 200 {object} Response{data=[]models.Gang} "获取进程组成功"
+// @Router /gangs [get]
+func listGangs(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取进程组成功",
+		Data:    scheduler.Gangs(),
+	})
+}
+
+// @Summary 获取锁
+// @Description 获取进程使用或持有的所有锁的持有者、等待者和天花板优先数，按锁名排序
+// @Produce json
+// @Success 200 {object} Response{data=[]models.Lock} "获取锁成功"
+// @Router /locks [get]
+func listLocks(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取锁成功",
+		Data:    scheduler.Locks(),
+	})
+}
+
+// @Summary 加载火星探路者号场景
+// @Description 重置系统为单处理机、动态优先数调度和指定的锁协议，然后提交重现优先级反转的负载：低优先数的 meteo 持有锁 bus 时，高优先数的 bus_dist 到达并等待该锁，中优先数的 comms 随后到达。其余系统参数保持不变
+// @Produce json
+// @Param protocol query string false "锁协议：none、inheritance、ceiling，默认为none"
+// @Success 200 {object} Response{data=WorkloadSubmitResponse} "场景已加载"
+// @Failure 400 {object} Response "加载失败"
+// @Router /scenarios/pathfinder [post]
+func loadPathfinder(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	protocol := models.LockProtocol(c.DefaultQuery("protocol", string(models.LockNone)))
+	mapping, err := scheduler.LoadPathfinder(protocol)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "场景加载失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: fmt.Sprintf("火星探路者号场景已加载，锁协议为 %s", protocol),
+		Data: WorkloadSubmitResponse{
+			Workload: services.PathfinderWorkload(),
+			Mapping:  mapping,
+		},
+	})
+}
+
+// @Summary 添加实时任务
+// @Description 添加周期或偶发实时任务。周期任务从当前时钟加上相位开始每个周期释放一个作业，偶发任务通过 /rt/tasks/{id}/release 释放作业；作业的运行时间为最坏执行时间，截止时刻为释放时刻加上相对截止时间（0表示等于周期）
+// @Accept json
+// @Produce json
+// @Param task body models.RealTimeTask true "实时任务，id、nextRelease、jobs由系统填写"
+// @Success 200 {object} Response{data=models.RealTimeTask} "添加成功"
+// @Failure 400 {object} Response "任务参数无效"
+// @Router /rt/tasks [post]
+func addTask(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var task models.RealTimeTask
+	if err := c.BindJSON(&task); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	if err := scheduler.AddTask(&task); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "添加实时任务失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "实时任务添加成功",
+		Data:    task,
+	})
+}
+
+// @Summary 获取实时任务
+// @Description 获取所有实时任务及其下一次释放时刻和已释放的作业数
+// @Produce json
+// @Success 200 {object} Response{data=[]models.RealTimeTask} "获取实时任务成功"
+// @Router /rt/tasks [get]
+func listTasks(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取实时任务成功",
+		Data:    scheduler.TaskList(),
+	})
+}
+
+// @Summary 删除实时任务
+// @Description 删除实时任务，不再释放新的作业，已释放的作业继续运行
+// @Produce json
+// @Param id path int true "任务ID"
+// @Success 200 {object} Response "删除成功"
+// @Failure 400 {object} Response "删除失败"
+// @Router /rt/tasks/{id} [delete]
+func removeTask(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var taskID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &taskID); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的任务ID",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	if err := scheduler.RemoveTask(taskID); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "删除实时任务失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: fmt.Sprintf("实时任务 %d 已删除", taskID),
+	})
+}
+
+// @Summary 释放偶发作业
+// @Description 在当前时钟释放偶发任务的一个作业，距上次释放不足最小间隔（周期）时失败
+// @Produce json
+// @Param id path int true "任务ID"
+// @Success 200 {object} Response{data=JobReleaseResponse} "释放成功"
+// @Failure 400 {object} Response "释放失败"
+// @Router /rt/tasks/{id}/release [post]
+func releaseJob(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var taskID int
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &taskID); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的任务ID",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	pid, err := scheduler.ReleaseJob(taskID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "释放作业失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: fmt.Sprintf("实时任务 %d 释放了作业 %d", taskID, pid),
+		Data:    JobReleaseResponse{PID: pid},
+	})
+}
+
+// @Summary 可调度性分析
+// @Description 对当前的实时任务集做单处理机可调度性分析：速率单调调度的Liu-Layland利用率界和响应时间分析，以及最早截止时间优先的利用率测试
+// @Produce json
+// @Success 200 {object} Response{data=models.SchedulabilityReport} "分析完成"
+// @Router /rt/analysis [get]
+func analyzeTasks(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "可调度性分析完成",
+		Data:    scheduler.AnalyzeTasks(),
+	})
+}
+
+// @Summary 获取处理机状态
+// @Description 获取所有处理机的当前运行状态，包括每个处理机上正在运行的进程信息
+// @Produce json
+// @Success 200 {object} Response{data=ProcessorStatusResponse} "获取处理机状态成功"
+// @Router /processor-status [get]
+func getProcessorStatus(c *gin.Context) {
+	state := currentScheduler(c).State()
+	processorCount := state.ProcessorCount
+	processors := make([]*models.PCB, processorCount)
+
+	for _, p := range state.Queue.Running {
+		if p.ProcessorID >= 0 && p.ProcessorID < processorCount {
+			processors[p.ProcessorID] = p
+		}
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取处理机状态成功",
+		Data: ProcessorStatusResponse{
+			Processors: processors,
+			Specs:      state.Processors,
+		},
+	})
+}
+
+// @Summary 获取前驱图
+// @Description 获取所有进程构成的前驱图，包括各进程的当前状态、前驱到后继的边以及按总运行时间计算的关键路径。format=dot 时返回Graphviz DOT文本
+// @Produce json
+// @Produce plain
+// @Param format query string false "输出格式：json（默认）或 dot"
+// @Success 200 {object} Response{data=models.PrecedenceGraph} "获取前驱图成功"
+// @Failure 400 {object} Response "不支持的格式"
+// @Router /graph [get]
+func getGraph(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	graph := scheduler.PrecedenceGraph()
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.JSON(http.StatusOK, Response{
+			Code:    0,
+			Message: "获取前驱图成功",
+			Data:    graph,
+		})
+	case "dot":
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(services.RenderDOT(graph)))
+	default:
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "不支持的格式，可选值为 json 或 dot",
+		})
+	}
+}
+
+// @Summary 获取调度统计
+// @Description 获取各进程的到达、开始、完成时刻以及周转时间、等待时间、响应时间，和系统的平均指标、吞吐量、处理机利用率
+// @Produce json
+// @Success 200 {object} Response{data=models.Stats} "获取统计成功"
+// @Router /stats [get]
+func getStats(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取统计成功",
+		Data:    scheduler.Stats(),
+	})
+}
+
+// @Summary 获取时间线
+// @Description 获取每个时钟周期各处理机上运行的进程，可用于绘制甘特图
+// @Produce json
+// @Success 200 {object} Response{data=[]models.TimelineSlot} "获取时间线成功"
+// @Router /timeline [get]
+func getTimeline(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取时间线成功",
+		Data:    scheduler.TimelineSlots(),
+	})
+}
+
+// @Summary 获取时间线事件
+// @Description 获取时间线上的系统事件，如处理机数量变化，tick 为事件发生后的第一个时钟周期
+// @Produce json
+// @Success 200 {object} Response{data=[]models.TimelineEvent} "获取时间线事件成功"
+// @Router /timeline/events [get]
+func getTimelineEvents(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "获取时间线事件成功",
+		Data:    scheduler.TimelineEvents(),
+	})
+}
+
+// @Summary 修改处理机数量
+// @Description 在运行中增减处理机。被移除的处理机上运行的进程被抢占回就绪队列，新增的处理机从下一次调度开始接收进程，变化记录在时间线事件中
+// @Accept json
+// @Produce json
+// @Param request body ProcessorCountRequest true "新的处理机数量"
+// @Success 200 {object} Response{data=StatusResponse} "修改成功"
+// @Failure 400 {object} Response "无效的处理机数量"
+// @Router /processors [put]
+func setProcessorCount(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var request ProcessorCountRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的请求参数",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	if err := scheduler.SetProcessorCount(request.Count); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "修改处理机数量失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "处理机数量已修改",
+		Data: statusResponse(scheduler),
+	})
+}
+
+// @Summary 对比调度策略
+// @Description 对每种调度策略与内存分配算法的组合，在独立的调度器和内存管理器上运行同一负载，返回各组合的平均等待时间、周转时间、响应时间、吞吐量和处理机利用率。不影响当前系统状态。一次最多对比20种组合，每种组合最多模拟20000个时钟周期
+// @Accept json
+// @Produce json
+// @Param request body CompareRequest true "负载及要对比的策略"
+// @Success 200 {object} Response{data=[]models.ComparisonRow} "对比完成"
+// @Failure 400 {object} Response "请求参数错误"
+// @Router /compare [post]
+func comparePolicies(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var request CompareRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	base := scheduler.Config()
+	if request.Config != nil {
+		base = *request.Config
+	}
+	if request.MaxTicks <= 0 {
+		request.MaxTicks = services.MaxCompareTicks
+	}
+
+	rows, err := services.ComparePolicies(&request.Workload, base, request.Policies, request.Allocators, request.MaxTicks)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "对比失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "对比完成",
+		Data:    rows,
+	})
+}
+
+// @Summary 生成合成负载
+// @Description 按指定的运行时间、优先数、内存大小分布，泊松到达过程和前驱图密度生成一组进程并提交到系统。相同的参数和种子总是生成相同的负载；进程到达时刻以当前时钟为基准。最多生成10000个进程，前驱图的期望边数不能超过100000
+// @Accept json
+// @Produce json
+// @Param config body models.GeneratorConfig true "生成参数"
+// @Success 200 {object} Response{data=WorkloadSubmitResponse} "负载已提交"
+// @Failure 400 {object} Response "生成或提交失败"
+// @Router /workload/generate [post]
+func generateWorkload(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var cfg models.GeneratorConfig
+	if err := c.BindJSON(&cfg); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "请求参数错误",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	workload, err := services.GenerateWorkload(cfg)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "负载生成失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	mapping, err := scheduler.SubmitWorkload(workload)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "负载提交失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: fmt.Sprintf("已提交 %d 个进程", len(workload.Processes)),
+		Data: WorkloadSubmitResponse{
+			Workload: workload,
+			Mapping:  mapping,
+		},
+	})
+}
+
+// @Summary 导入负载
+// @Description 导入JSON或CSV格式的负载并提交到系统，格式说明见 readme。未指定format时，Content-Type为text/csv按CSV解析，否则按JSON解析；进程到达时刻以当前时钟为基准
+// @Accept json
+// @Accept plain
+// @Produce json
+// @Param format query string false "负载格式：json 或 csv"
+// @Param name query string false "负载名称，CSV格式不包含名称时使用"
+// @Param workload body models.Workload true "负载内容"
+// @Success 200 {object} Response{data=WorkloadSubmitResponse} "负载已提交"
+// @Failure 400 {object} Response "解析或提交失败"
+// @Router /workload/import [post]
+func importWorkload(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	format := c.Query("format")
+	if format == "" {
+		format = services.WorkloadJSON
+		if c.ContentType() == "text/csv" {
+			format = services.WorkloadCSV
+		}
+	}
+
+	workload, err := services.ParseWorkload(c.Request.Body, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "负载解析失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+	if name := c.Query("name"); name != "" {
+		workload.Name = name
+	}
+
+	mapping, err := scheduler.SubmitWorkload(workload)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "负载提交失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: fmt.Sprintf("已提交 %d 个进程", len(workload.Processes)),
+		Data: WorkloadSubmitResponse{
+			Workload: workload,
+			Mapping:  mapping,
+		},
+	})
+}
+
+// @Summary 导出负载
+// @Description 将当前各队列中的进程导出为与导入相同格式的负载，到达时间以最早到达的进程为0
+// @Produce json
+// @Produce plain
+// @Param format query string false "负载格式：json（默认）或 csv"
+// @Param name query string false "负载名称"
+// @Param includeFinished query bool false "是否导出已完成的进程"
+// @Success 200 {object} models.Workload "负载内容"
+// @Failure 400 {object} Response "不支持的格式"
+// @Router /workload/export [get]
+func exportWorkload(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	format := c.DefaultQuery("format", services.WorkloadJSON)
+	includeFinished := c.Query("includeFinished") == "true"
+	workload := scheduler.ExportWorkload(c.DefaultQuery("name", "exported"), includeFinished)
+
+	data, err := services.EncodeWorkload(workload, format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "负载导出失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	contentType := "application/json; charset=utf-8"
+	if format == services.WorkloadCSV {
+		contentType = "text/csv; charset=utf-8"
+	}
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// @Summary 重置系统
+// @Description 强制重启整个系统，清空所有进程和内存。这将终止所有正在运行的进程，释放所有内存分配，并将系统恢复到初始状态。系统参数（如处理机数量、最大进程数）将保持不变。
+// @Tags system
+// @Accept json
+// @Produce json
+// @Success 200 {object} Response "系统重置成功"
+// @Failure 500 {object} Response "系统重置失败"
+// @Router /reset [post]
+func resetSystem(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	// 清空调度器和内存管理器，保留系统参数和自动保存设置
+	scheduler.Reset()
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "系统已重置",
+		Data:    nil,
+	})
+}
+
+
+// @Summary 获取系统参数
+// @Tags system
+// @Produce json
+// @Success 200 {object} Response{data=models.SystemConfig} "成功获取系统参数"
+// @Router /config [get]
+func getConfig(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "成功获取系统参数",
+		Data:    scheduler.Config(),
+	})
+}
+
+// @Summary 修改系统参数
+// @Description 请求中未出现的参数保持不变，用户组份额 groupShares 出现时整体替换。道数、调度策略、时间片和内存分配算法立即生效；修改处理机数量或内存大小需要重置系统，未指定 reset=true 时返回409及需要重置的参数列表。指定 reset=true 时总是先清空所有进程
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param config body models.SystemConfig true "系统参数"
+// @Param reset query bool false "是否重置系统后应用"
+// @Success 200 {object} Response{data=models.SystemConfig} "修改成功"
+// @Failure 400 {object} Response "无效的系统参数"
+// @Failure 409 {object} Response{data=[]string} "需要重置系统才能修改的参数"
+// @Router /config [put]
+func updateConfig(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	cfg := scheduler.Config()
+	// 份额表整体替换而不是与当前的份额合并，请求中没有时保持不变
+	shares := cfg.GroupShares
+	cfg.GroupShares = nil
+	if err := c.ShouldBindJSON(&cfg); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的请求参数",
+			Data:    err.Error(),
+		})
+		return
+	}
+	if cfg.GroupShares == nil {
+		cfg.GroupShares = shares
+	}
+	reset := c.Query("reset") == "true"
+
+	if err := scheduler.Configure(cfg, reset); err != nil {
+		var conflict *services.ConfigConflictError
+		if errors.As(err, &conflict) {
+			c.JSON(http.StatusConflict, Response{
+				Code:    409,
+				Message: err.Error(),
+				Data:    conflict.Fields,
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "无效的系统参数",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "系统参数已更新",
+		Data:    scheduler.Config(),
+	})
+}
+
+// @Summary 保存快照
+// @Description 将调度器（各进程队列、下一个PID、时钟、系统参数）和内存分区状态保存到服务端快照文件，并在响应中返回快照内容
+// @Tags system
+// @Produce json
+// @Success 200 {object} Response{data=models.Snapshot} "快照保存成功"
+// @Failure 500 {object} Response "快照保存失败"
+// @Router /snapshot [post]
+func saveSnapshot(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	if err := scheduler.SaveSnapshot(sessionSnapshotFile(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, Response{
+			Code:    500,
+			Message: "快照保存失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: fmt.Sprintf("快照已保存到 %s", sessionSnapshotFile(c)),
+		Data:    scheduler.Snapshot(),
+	})
+}
+
+// @Summary 恢复快照
+// @Description 用请求体中的快照恢复系统状态；请求体为空或未提供快照时从服务端快照文件恢复
+// @Tags system
+// @Accept json
+// @Produce json
+// @Param request body RestoreRequest false "要恢复的快照"
+// @Success 200 {object} Response "快照恢复成功"
+// @Failure 400 {object} Response "快照恢复失败"
+// @Router /restore [post]
+func restoreSnapshot(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	var request RestoreRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&request); err != nil {
+			c.JSON(http.StatusBadRequest, Response{
+				Code:    400,
+				Message: "请求参数错误",
+				Data:    err.Error(),
+			})
+			return
+		}
+	}
+
+	var err error
+	if request.Snapshot != nil {
+		err = scheduler.Restore(request.Snapshot)
+	} else {
+		err = scheduler.LoadSnapshot(sessionSnapshotFile(c))
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "快照恢复失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "快照恢复成功",
+	})
+}
+
+// @Summary 重放事件日志
+// @Description 清空系统后依次重放请求体中的JSONL事件日志，重建与记录时完全相同的状态。每个调用的结果都会与日志比对，不一致时停止重放
+// @Tags system
+// @Accept plain
+// @Produce json
+// @Param log body string true "JSONL格式的事件日志，每行一条事件"
+// @Success 200 {object} Response{data=StatusResponse} "重放成功"
+// @Failure 400 {object} Response "重放失败"
+// @Router /replay [post]
+func replayEventLog(c *gin.Context) {
+	scheduler := currentScheduler(c)
+	if err := scheduler.Replay(c.Request.Body); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "重放失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "重放成功",
+		Data: statusResponse(scheduler),
+	})
+}
+
+// @Summary 创建会话
+// @Description 创建一个拥有独立调度器和内存管理器的模拟会话。会话ID为空时自动生成，系统参数为空时使用默认参数。创建后在接口路径前加上 /sessions/{id} 即可操作该会话
+// @Tags session
+// @Accept json
+// @Produce json
+// @Param request body SessionCreateRequest false "会话ID和系统参数"
+// @Success 200 {object} Response{data=models.SessionInfo} "创建成功"
+// @Failure 400 {object} Response "创建失败"
+// @Router /sessions [post]
+func createSession(c *gin.Context) {
+	// 请求中未出现的系统参数使用启动时的系统参数
+	cfg := systemConfig
+	req := SessionCreateRequest{Config: &cfg}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, Response{
+				Code:    400,
+				Message: "无效的请求参数",
+				Data:    err.Error(),
+			})
+			return
+		}
+	}
+
+	if req.Config == nil {
+		req.Config = &systemConfig
+	}
+	session, err := sessions.Create(req.ID, *req.Config)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "创建会话失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "会话创建成功",
+		Data:    session.Info(),
+	})
+}
+
+// @Summary 获取会话列表
+// @Description 按创建时间列出所有会话
+// @Tags session
+// @Produce json
+// @Success 200 {object} Response{data=[]models.SessionInfo} "成功获取会话列表"
+// @Router /sessions [get]
+func listSessions(c *gin.Context) {
+	infos := make([]models.SessionInfo, 0)
+	for _, session := range sessions.List() {
+		infos = append(infos, session.Info())
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "成功获取会话列表",
+		Data:    infos,
+	})
+}
+
+// @Summary 获取会话信息
+// @Tags session
+// @Produce json
+// @Param id path string true "会话ID"
+// @Success 200 {object} Response{data=models.SessionInfo} "成功获取会话信息"
+// @Failure 404 {object} Response "会话不存在"
+// @Router /sessions/{id} [get]
+func getSession(c *gin.Context) {
+	session, ok := sessions.Get(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, Response{
+			Code:    404,
+			Message: fmt.Sprintf("找不到会话 %s", c.Param("id")),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "成功获取会话信息",
+		Data:    session.Info(),
+	})
+}
+
+// @Summary 删除会话
+// @Description 删除会话及其全部状态，默认会话不能删除
+// @Tags session
+// @Produce json
+// @Param id path string true "会话ID"
+// @Success 200 {object} Response "删除成功"
+// @Failure 400 {object} Response "删除失败"
+// @Router /sessions/{id} [delete]
+func deleteSession(c *gin.Context) {
+	if err := sessions.Delete(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, Response{
+			Code:    400,
+			Message: "删除会话失败",
+			Data:    err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, Response{
+		Code:    0,
+		Message: "会话已删除",
 	})
 }
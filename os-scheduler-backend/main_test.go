@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os-scheduler-backend/services"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newTestRouter 创建只有默认会话的路由
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	sessions = services.NewSessionManager(0)
+	if _, err := sessions.Create(services.DefaultSessionID, services.DefaultConfig()); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	r := gin.New()
+	registerSystemRoutes(r.Group("/", useDefaultSession))
+	return r
+}
+
+// request 发送请求并解码响应，状态码不是200时测试失败
+func request(t *testing.T, r *gin.Engine, method, path, body string, data interface{}) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("%s %s: status %d: %s", method, path, w.Code, w.Body.String())
+	}
+	if data != nil {
+		response := Response{Data: data}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+	}
+}
+
+func TestUpdateConfigReplacesGroupShares(t *testing.T) {
+	r := newTestRouter(t)
+	var cfg struct {
+		GroupShares map[string]int `json:"groupShares"`
+	}
+	request(t, r, http.MethodPut, "/config", `{"groupShares": {"lab": 3, "course": 1}}`, &cfg)
+	if len(cfg.GroupShares) != 2 {
+		t.Fatalf("shares = %v, want lab and course", cfg.GroupShares)
+	}
+
+	// 请求中的份额表整体替换原来的份额表
+	cfg.GroupShares = nil
+	request(t, r, http.MethodPut, "/config", `{"groupShares": {"lab": 2}}`, &cfg)
+	if len(cfg.GroupShares) != 1 || cfg.GroupShares["lab"] != 2 {
+		t.Fatalf("shares = %v, want only lab=2", cfg.GroupShares)
+	}
+
+	// 没有份额表时保持不变
+	cfg.GroupShares = nil
+	request(t, r, http.MethodPut, "/config", `{"timeQuantum": 3}`, &cfg)
+	if len(cfg.GroupShares) != 1 || cfg.GroupShares["lab"] != 2 {
+		t.Fatalf("shares = %v after an unrelated update, want lab=2", cfg.GroupShares)
+	}
+
+	cfg.GroupShares = nil
+	request(t, r, http.MethodPut, "/config", `{"groupShares": {}}`, &cfg)
+	if len(cfg.GroupShares) != 0 {
+		t.Errorf("shares = %v, want none", cfg.GroupShares)
+	}
+}
+
+func TestHandlersReadStateUnderLock(t *testing.T) {
+	r := newTestRouter(t)
+	for i := 0; i < 4; i++ {
+		request(t, r, http.MethodPost, "/process", `{"name": "p", "requiredTime": 20, "memorySize": 16}`, nil)
+	}
+
+	// 调度与读取状态的请求并发执行，在 -race 下检查处理函数不在锁外读取调度器的状态
+	reads := []string{"/status", "/timeline", "/timeline/events", "/processor-status"}
+	serve := func(method, path, body string) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		if w.Code != http.StatusOK {
+			t.Errorf("%s %s: status %d: %s", method, path, w.Code, w.Body.String())
+		}
+	}
+	var wg sync.WaitGroup
+	wg.Add(1 + len(reads))
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			serve(http.MethodPost, "/schedule", "")
+			serve(http.MethodPut, "/processors", fmt.Sprintf(`{"count": %d}`, 1+i%3))
+		}
+	}()
+	for _, path := range reads {
+		go func(path string) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				serve(http.MethodGet, path, "")
+			}
+		}(path)
+	}
+	wg.Wait()
+
+	var rewind RewindResponse
+	request(t, r, http.MethodPost, "/rewind?steps=5", "", &rewind)
+	if rewind.Steps != 5 || rewind.Clock != 14 {
+		t.Errorf("rewind = %d steps to clock %d, want 5 steps to clock 14", rewind.Steps, rewind.Clock)
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+// BatchProcess 批量提交中的单个进程，前驱通过批内ID引用
+type BatchProcess struct {
+	ID               string            `json:"id"` // 批内ID，在同一批次中唯一
+	Name             string            `json:"name"`
+	RequiredTime     int               `json:"requiredTime"`
+	Priority         int               `json:"priority"`
+	MemorySize       int               `json:"memorySize"`
+	AffinityMask     uint64            `json:"affinityMask"`     // 允许运行的处理机位掩码，0表示不限制
+	Gang             string            `json:"gang"`             // 所属进程组，组内进程同时运行
+	Tickets          int               `json:"tickets"`          // 比例份额调度的彩票数，0表示默认值
+	User             string            `json:"user"`             // 所属用户，为空表示默认用户
+	Group            string            `json:"group"`            // 所属用户组，为空表示默认组
+	CriticalSections []CriticalSection `json:"criticalSections"` // 临界区
+	Predecessors     []string          `json:"predecessors"`     // 批内前驱ID列表
+	PredecessorPIDs  []int             `json:"predecessorPids"`  // 系统中已存在的前驱PID列表
+}
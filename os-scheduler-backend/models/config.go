@@ -0,0 +1,54 @@
+package models
+
+// SchedulingPolicy 进程调度策略
+type SchedulingPolicy string
+
+const (
+	PolicyPriority  SchedulingPolicy = "priority"  // 动态优先数，每个时间片后重新调度
+	PolicyFCFS      SchedulingPolicy = "fcfs"      // 先来先服务，非抢占
+	PolicySJF       SchedulingPolicy = "sjf"       // 最短剩余时间优先，抢占式
+	PolicyRR        SchedulingPolicy = "rr"        // 时间片轮转
+	PolicyRM        SchedulingPolicy = "rm"        // 速率单调，周期越短的实时作业优先级越高
+	PolicyEDF       SchedulingPolicy = "edf"       // 最早截止时间优先
+	PolicyLottery   SchedulingPolicy = "lottery"   // 彩票调度，按彩票数随机抽取
+	PolicyStride    SchedulingPolicy = "stride"    // 步长调度，按彩票数确定性地分配
+	PolicyCFS       SchedulingPolicy = "cfs"       // 完全公平调度，虚拟运行时间最小的进程优先
+	PolicyFairShare SchedulingPolicy = "fairshare" // 公平份额调度，先在用户组之间按份额分配，再在组内按彩票数分配
+)
+
+// ProcessorSpec 单个处理机的规格
+type ProcessorSpec struct {
+	Speed float64 `json:"speed"`          // 每个时钟周期完成的工作量，0表示1
+	Type  string  `json:"type,omitempty"` // 处理机类型，如 big、little
+}
+
+// SystemConfig 系统参数
+type SystemConfig struct {
+	ProcessorCount int                 `json:"processorCount"`       // 处理机数量
+	Processors     []ProcessorSpec     `json:"processors,omitempty"` // 各处理机的规格，缺少的处理机速度为1
+	MaxProcesses   int                 `json:"maxProcesses"`         // 道数
+	TotalMemory    int                 `json:"totalMemory"`          // 内存总大小
+	OSMemory       int                 `json:"osMemory"`             // 操作系统占用的内存大小
+	Policy         SchedulingPolicy    `json:"policy"`               // 调度策略
+	TimeQuantum    int                 `json:"timeQuantum"`          // 时间片轮转的时间片长度
+	Allocator      AllocationAlgorithm `json:"allocator"`            // 内存分配算法
+
+	PerProcessorQueues bool `json:"perProcessorQueues"` // 每个处理机使用独立的就绪队列
+	MigrationCost      int  `json:"migrationCost"`      // 进程换到另一个处理机时额外占用的时钟周期数
+	BalanceInterval    int  `json:"balanceInterval"`    // 独立就绪队列之间负载均衡的间隔，0表示只在处理机空闲时拉取
+
+	Seed uint64 `json:"seed"` // 彩票调度使用的随机数种子
+
+	TargetLatency  int `json:"targetLatency"`  // 完全公平调度中每个可运行进程至少运行一次的周期
+	MinGranularity int `json:"minGranularity"` // 完全公平调度中每次占用处理机的最短时间
+
+	AgingInterval int  `json:"agingInterval"` // 动态优先数策略下进程每连续等待多少个时钟周期提高一次优先数，0表示不老化
+	AgingStep     int  `json:"agingStep"`     // 每次老化增加的优先数
+	AgeBackup     bool `json:"ageBackup"`     // 后备队列中的进程也参与老化，并按优先数调入
+
+	LockProtocol LockProtocol `json:"lockProtocol"` // 锁协议：none、inheritance、ceiling
+
+	ContextSwitchCost int `json:"contextSwitchCost"` // 处理机换一个进程运行时上下文切换占用的时钟周期数
+
+	GroupShares map[string]int `json:"groupShares,omitempty"` // 公平份额调度中各用户组的份额，未列出的组为默认值1
+}
@@ -0,0 +1,10 @@
+package models
+
+import "encoding/json"
+
+// Event 事件日志中的一条记录，对应一次改变系统状态的调用
+type Event struct {
+	Seq  int             `json:"seq"`            // 从1开始的序号
+	Op   string          `json:"op"`             // 操作名称
+	Args json.RawMessage `json:"args,omitempty"` // 操作参数
+}
@@ -0,0 +1,7 @@
+package models
+
+// Gang 进程组：组内进程只在同一个时钟周期内同时占用不同的处理机时才运行
+type Gang struct {
+	Name string `json:"name"`
+	PIDs []int  `json:"pids"`
+}
@@ -0,0 +1,30 @@
+package models
+
+// 随机分布类型
+const (
+	DistributionUniform     = "uniform"     // 在[Min, Max]上均匀分布
+	DistributionExponential = "exponential" // 均值为Mean的指数分布
+	DistributionBimodal     = "bimodal"     // 以LongRatio的概率取均值为LongMean的指数分布，否则取均值为Mean的指数分布
+)
+
+// Distribution 整数随机量的分布，结果会被限制在[Min, Max]内，Max为0表示没有上限
+type Distribution struct {
+	Kind      string  `json:"kind"`
+	Min       int     `json:"min"`
+	Max       int     `json:"max"`
+	Mean      float64 `json:"mean"`
+	LongMean  float64 `json:"longMean"`
+	LongRatio float64 `json:"longRatio"`
+}
+
+// GeneratorConfig 合成负载的生成参数，相同的参数和种子总是生成相同的负载
+type GeneratorConfig struct {
+	Name        string       `json:"name"`
+	Seed        int64        `json:"seed"`
+	Count       int          `json:"count"`       // 进程数
+	Burst       Distribution `json:"burst"`       // 运行时间分布
+	Priority    Distribution `json:"priority"`    // 优先数分布
+	Memory      Distribution `json:"memory"`      // 内存大小分布
+	ArrivalRate float64      `json:"arrivalRate"` // 泊松到达过程中每个时钟周期平均到达的进程数，为0时所有进程同时到达
+	DAGDensity  float64      `json:"dagDensity"`  // 任意两个进程之间存在前驱关系的概率
+}
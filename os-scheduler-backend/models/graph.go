@@ -0,0 +1,25 @@
+package models
+
+// GraphNode 前驱图中的进程节点
+type GraphNode struct {
+	PID            int          `json:"pid"`
+	Name           string       `json:"name"`
+	State          ProcessState `json:"state"`
+	TotalTime      int          `json:"totalTime"`
+	RemainingTime  int          `json:"remainingTime"`
+	OnCriticalPath bool         `json:"onCriticalPath"`
+}
+
+// GraphEdge 前驱图中的边，由前驱指向后继
+type GraphEdge struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// PrecedenceGraph 整个系统的前驱图
+type PrecedenceGraph struct {
+	Nodes              []GraphNode `json:"nodes"`
+	Edges              []GraphEdge `json:"edges"`
+	CriticalPath       []int       `json:"criticalPath"`       // 关键路径上的PID，按执行顺序排列
+	CriticalPathLength int         `json:"criticalPathLength"` // 关键路径上各进程总运行时间之和
+}
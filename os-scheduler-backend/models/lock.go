@@ -0,0 +1,25 @@
+package models
+
+// LockProtocol 锁协议，决定持有锁的进程的有效优先数如何调整
+type LockProtocol string
+
+const (
+	LockNone        LockProtocol = "none"        // 不调整优先数，可能发生优先级反转
+	LockInheritance LockProtocol = "inheritance" // 优先级继承：持有者继承等待该锁的进程中的最大优先数
+	LockCeiling     LockProtocol = "ceiling"     // 优先级天花板：持有者获得锁后立即提升到锁的天花板优先数
+)
+
+// CriticalSection 进程执行到某个位置时需要持有锁的一段代码
+type CriticalSection struct {
+	Lock   string `json:"lock"`   // 锁名
+	Start  int    `json:"start"`  // 进入临界区时已完成的工作量
+	Length int    `json:"length"` // 临界区内的工作量
+}
+
+// Lock 锁的当前状态
+type Lock struct {
+	Name    string `json:"name"`
+	Holder  int    `json:"holder"`  // 持有锁的进程，0表示空闲
+	Waiters []int  `json:"waiters"` // 因等待该锁阻塞的进程，按阻塞先后排序
+	Ceiling int    `json:"ceiling"` // 天花板优先数：会使用该锁的未完成进程的最大基础优先数
+}
@@ -6,8 +6,20 @@ type MemoryBlock struct {
     IsUsed   bool `json:"isUsed"`
 }
 
+// AllocationAlgorithm 内存分配算法
+type AllocationAlgorithm string
+
+const (
+    FirstFit AllocationAlgorithm = "first_fit" // 首次适应
+    NextFit  AllocationAlgorithm = "next_fit"  // 循环首次适应
+    BestFit  AllocationAlgorithm = "best_fit"  // 最佳适应
+    WorstFit AllocationAlgorithm = "worst_fit" // 最坏适应
+)
+
 type MemoryManager struct {
-    TotalSize    int           `json:"totalSize"`
-    OSSize       int           `json:"osSize"`
-    Blocks       []MemoryBlock `json:"blocks"`
+    TotalSize    int                 `json:"totalSize"`
+    OSSize       int                 `json:"osSize"`
+    Blocks       []MemoryBlock       `json:"blocks"`
+    Algorithm    AllocationAlgorithm `json:"algorithm"`
+    NextFitStart int                 `json:"nextFitStart"` // 循环首次适应下次开始查找的地址
 }
\ No newline at end of file
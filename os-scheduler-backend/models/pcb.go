@@ -3,11 +3,13 @@ package models
 type ProcessState string
 
 const (
-	Ready     ProcessState = "ready"
-	Running   ProcessState = "running"
-	Waiting   ProcessState = "waiting"
-	Finished  ProcessState = "finished"
-	Suspended ProcessState = "suspended"
+	New              ProcessState = "new" // 已提交但尚未到达或尚未分配到内存
+	Ready            ProcessState = "ready"
+	Running          ProcessState = "running"
+	Waiting          ProcessState = "waiting"
+	Finished         ProcessState = "finished"
+	SuspendedReady   ProcessState = "suspended_ready"   // 挂起前就绪或运行，恢复后回到就绪队列
+	SuspendedBlocked ProcessState = "suspended_blocked" // 挂起前在等待队列中，恢复后仍需等待
 )
 
 type PCB struct {
@@ -16,10 +18,43 @@ type PCB struct {
 	RequiredTime      int         `json:"requiredTime"` // 剩余运行时间
 	TotalRequiredTime int         `json:"totalTime"`    // 总运行时间
 	Priority          int         `json:"priority"`
+	InitialPriority   int          `json:"initialPriority"` // 提交时的优先数，导出负载时使用
 	State             ProcessState `json:"state"`
 	MemorySize        int          `json:"memorySize"`
 	MemoryStart       int          `json:"memoryStart"`
 	ProcessorID       int          `json:"processorId"`  // -1表示未分配处理机
 	Predecessors      []int        `json:"predecessors"` // 前驱进程PID列表
 	Successors        []int        `json:"successors"`   // 后继进程PID列表
+	ArrivalTime       int          `json:"arrivalTime"`  // 到达时刻
+	StartTime         int          `json:"startTime"`    // 首次运行时刻，-1表示尚未运行
+	FinishTime        int          `json:"finishTime"`   // 完成时刻
+	CPUTime           int          `json:"cpuTime"`      // 已占用处理机的时间
+	QuantumUsed       int          `json:"quantumUsed"`  // 本次占用处理机已用的时间片
+	AffinityMask      uint64       `json:"affinityMask"`  // 允许运行的处理机位掩码，0表示不限制
+	LastProcessor     int          `json:"lastProcessor"` // 上次运行的处理机，-1表示尚未运行
+	RunQueue          int          `json:"runQueue"`      // 按处理机划分就绪队列时所在的队列，-1表示未分配
+	Migrations        int          `json:"migrations"`    // 换到另一个处理机上运行的次数
+	StallTicks        int          `json:"stallTicks"`    // 剩余的迁移开销，期间占用处理机但不推进
+	SwitchTicks       int          `json:"switchTicks"`   // 剩余的上下文切换开销，期间占用处理机但不推进，不计入时间片
+	WorkCarry         int          `json:"workCarry"`     // 不足一个时间单位的已完成工作量，单位为千分之一
+	Gang              string       `json:"gang"`          // 所属进程组，为空表示不属于任何组
+	TaskID            int          `json:"taskId"`         // 所属实时任务，0表示不是实时作业
+	Period            int          `json:"period"`         // 实时作业所属任务的周期
+	Deadline          int          `json:"deadline"`       // 实时作业的绝对截止时刻
+	DeadlineMissed    bool         `json:"deadlineMissed"` // 实时作业是否错过了截止时刻
+	Tickets           int          `json:"tickets"`        // 比例份额调度的彩票数，0表示默认值
+	Pass              int          `json:"pass"`           // 步长调度的行程值，每运行一个时钟周期增加一个步长
+	Entitled          float64      `json:"entitled"`       // 按彩票数应得的处理机时间
+	VRuntime          int          `json:"vruntime"`       // 按权重折算的虚拟运行时间，单位为nice 0进程的千分之一时钟周期
+	WaitTicks         int          `json:"waitTicks"`      // 在就绪或后备队列中连续等待的时钟周期数
+	MaxWait           int          `json:"maxWait"`        // 最长的一次连续等待
+	CriticalSections  []CriticalSection `json:"criticalSections"` // 按开始位置排序、互不重叠的临界区
+	HeldLock          string       `json:"heldLock"`       // 当前持有的锁，为空表示未持有
+	BlockedOn         string       `json:"blockedOn"`      // 正在等待的锁，为空表示未因锁阻塞
+	Boost             int          `json:"boost"`          // 锁协议临时提高的优先数，优先数减去它为基础优先数
+	LockWait          int          `json:"lockWait"`       // 因等待锁阻塞的时钟周期数
+	History           []StateChange `json:"history"`       // 状态转换历史
+	User              string       `json:"user"`           // 所属用户，为空表示默认用户
+	Group             string       `json:"group"`          // 所属用户组，为空表示默认组
+	FairPass          int          `json:"fairPass"`       // 公平份额调度的行程值
 }
@@ -1,9 +1,11 @@
 package models
 
 type ProcessQueue struct {
+    New       []*PCB `json:"new"`
     Ready     []*PCB `json:"ready"`
     Running   []*PCB `json:"running"`
     Waiting   []*PCB `json:"waiting"`
     Backup    []*PCB `json:"backup"`
     Suspended []*PCB `json:"suspended"`
+    Finished  []*PCB `json:"finished"`
 }
\ No newline at end of file
@@ -0,0 +1,59 @@
+package models
+
+// RealTimeTask 周期或偶发实时任务，每次释放产生一个作业进程
+type RealTimeTask struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Period      int    `json:"period"`      // 周期；偶发任务为相邻两次释放的最小间隔
+	WCET        int    `json:"wcet"`        // 最坏情况执行时间，即每个作业的运行时间
+	Deadline    int    `json:"deadline"`    // 相对截止时间，不超过周期，0表示等于周期
+	Phase       int    `json:"phase"`       // 第一个作业相对任务添加时刻的释放时间
+	Sporadic    bool   `json:"sporadic"`    // 偶发任务只在请求释放时产生作业
+	MemorySize  int    `json:"memorySize"`  // 每个作业的内存大小
+	NextRelease int    `json:"nextRelease"` // 周期任务下一个作业的释放时刻；偶发任务最早可以再次释放的时刻
+	Jobs        int    `json:"jobs"`        // 已释放的作业数
+}
+
+// TaskStats 实时任务的作业统计
+type TaskStats struct {
+	TaskID      int    `json:"taskId"`
+	Name        string `json:"name"`
+	Released    int    `json:"released"`    // 已释放的作业数
+	Completed   int    `json:"completed"`   // 已完成的作业数
+	Missed      int    `json:"missed"`      // 错过截止时刻的作业数
+	MaxResponse int    `json:"maxResponse"` // 已完成作业从释放到完成的最长时间
+}
+
+// TaskAnalysis 单个实时任务的可调度性分析结果
+type TaskAnalysis struct {
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	Period       int     `json:"period"`
+	WCET         int     `json:"wcet"`
+	Deadline     int     `json:"deadline"`
+	Utilization  float64 `json:"utilization"`  // WCET/周期
+	ResponseTime int     `json:"responseTime"` // 速率单调优先级下的最坏响应时间，超过截止时间时为迭代停止时的值
+	Schedulable  bool    `json:"schedulable"`  // 最坏响应时间不超过截止时间
+}
+
+// SchedulabilityReport 实时任务集在单处理机上的可调度性分析
+type SchedulabilityReport struct {
+	Tasks             []TaskAnalysis `json:"tasks"`
+	Utilization       float64        `json:"utilization"`       // 总利用率
+	LiuLaylandBound   float64        `json:"liuLaylandBound"`   // n(2^(1/n)-1)
+	ImplicitDeadlines bool           `json:"implicitDeadlines"` // 所有任务的截止时间都等于周期
+	RMBoundTest       bool           `json:"rmBoundTest"`       // 截止时间等于周期且利用率不超过Liu-Layland界，不满足时仍可能可调度
+	RMSchedulable     bool           `json:"rmSchedulable"`     // 响应时间分析：所有任务在速率单调调度下都满足截止时间
+	EDFSchedulable    bool           `json:"edfSchedulable"`    // 截止时间等于周期时利用率不超过1；否则密度不超过1（充分条件）
+	Density           float64        `json:"density"`           // 各任务 WCET/截止时间 之和
+}
+
+// CloneTasks 深拷贝实时任务
+func CloneTasks(tasks []*RealTimeTask) []*RealTimeTask {
+	clone := make([]*RealTimeTask, 0, len(tasks))
+	for _, task := range tasks {
+		t := *task
+		clone = append(clone, &t)
+	}
+	return clone
+}
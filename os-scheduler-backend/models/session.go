@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// SessionInfo 模拟会话的概要信息
+type SessionInfo struct {
+	ID        string       `json:"id"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Config    SystemConfig `json:"config"`
+	Clock     int          `json:"clock"`
+	Processes int          `json:"processes"` // 会话中的进程总数，包括已完成的进程
+}
@@ -0,0 +1,98 @@
+package models
+
+// SnapshotVersion 快照文件格式版本
+const SnapshotVersion = 1
+
+// Snapshot 调度器与内存管理器的完整状态快照
+type Snapshot struct {
+	Version            int              `json:"version"`
+	Clock              int              `json:"clock"`   // 已执行的时钟周期数
+	NextPID            int              `json:"nextPid"` // 下一个分配的PID
+	ProcessorCount     int              `json:"processorCount"`
+	Processors         []ProcessorSpec  `json:"processors,omitempty"`
+	MaxProcesses       int              `json:"maxProcesses"`
+	Policy             SchedulingPolicy `json:"policy"`
+	TimeQuantum        int              `json:"timeQuantum"`
+	PerProcessorQueues bool             `json:"perProcessorQueues"`
+	MigrationCost      int              `json:"migrationCost"`
+	BalanceInterval    int              `json:"balanceInterval"`
+	Seed               uint64           `json:"seed"`
+	RandomState        uint64           `json:"randomState"` // 随机数生成器的当前状态
+	TargetLatency      int              `json:"targetLatency"`
+	MinGranularity     int              `json:"minGranularity"`
+	MinVRuntime        int              `json:"minVruntime"` // 可运行进程的最小虚拟运行时间，单调不减
+	AgingInterval      int              `json:"agingInterval"`
+	AgingStep          int              `json:"agingStep"`
+	AgeBackup          bool             `json:"ageBackup"`
+	LockProtocol       LockProtocol     `json:"lockProtocol"`
+	ContextSwitchCost  int              `json:"contextSwitchCost"`
+	GroupShares        map[string]int   `json:"groupShares,omitempty"`
+	Started            bool             `json:"started"` // 是否已做出过调度决策
+	Timeline           []TimelineSlot   `json:"timeline"`
+	Events             []TimelineEvent  `json:"events,omitempty"`
+	Tasks              []*RealTimeTask  `json:"tasks,omitempty"`
+	NextTaskID         int              `json:"nextTaskId,omitempty"`
+	Queue              *ProcessQueue    `json:"queue"`
+	Memory             *MemoryManager   `json:"memory"`
+}
+
+// Clone 深拷贝进程控制块
+func (p *PCB) Clone() *PCB {
+	clone := *p
+	clone.Predecessors = append([]int(nil), p.Predecessors...)
+	clone.Successors = append([]int(nil), p.Successors...)
+	clone.CriticalSections = append([]CriticalSection(nil), p.CriticalSections...)
+	clone.History = append([]StateChange(nil), p.History...)
+	return &clone
+}
+
+// Clone 深拷贝所有进程队列
+func (q *ProcessQueue) Clone() *ProcessQueue {
+	return &ProcessQueue{
+		New:       clonePCBs(q.New),
+		Ready:     clonePCBs(q.Ready),
+		Running:   clonePCBs(q.Running),
+		Waiting:   clonePCBs(q.Waiting),
+		Backup:    clonePCBs(q.Backup),
+		Suspended: clonePCBs(q.Suspended),
+		Finished:  clonePCBs(q.Finished),
+	}
+}
+
+// Clone 深拷贝内存状态
+func (m *MemoryManager) Clone() *MemoryManager {
+	clone := *m
+	clone.Blocks = append(make([]MemoryBlock, 0, len(m.Blocks)), m.Blocks...)
+	return &clone
+}
+
+// CloneTimeline 深拷贝时间线
+func CloneTimeline(timeline []TimelineSlot) []TimelineSlot {
+	clone := make([]TimelineSlot, 0, len(timeline))
+	for _, slot := range timeline {
+		clone = append(clone, TimelineSlot{
+			Tick:       slot.Tick,
+			Processors: append([]int(nil), slot.Processors...),
+			Switching:  append([]bool(nil), slot.Switching...),
+		})
+	}
+	return clone
+}
+
+// CloneEvents 深拷贝时间线事件
+func CloneEvents(events []TimelineEvent) []TimelineEvent {
+	clone := make([]TimelineEvent, 0, len(events))
+	for _, event := range events {
+		event.PIDs = append([]int(nil), event.PIDs...)
+		clone = append(clone, event)
+	}
+	return clone
+}
+
+func clonePCBs(processes []*PCB) []*PCB {
+	clones := make([]*PCB, 0, len(processes))
+	for _, p := range processes {
+		clones = append(clones, p.Clone())
+	}
+	return clones
+}
@@ -0,0 +1,9 @@
+package models
+
+// StateChange 进程的一次状态转换
+type StateChange struct {
+	Tick   int          `json:"tick"` // 新状态从该时钟周期开始生效
+	From   ProcessState `json:"from"` // 为空表示刚创建的进程
+	To     ProcessState `json:"to"`
+	Reason string       `json:"reason"`
+}
@@ -0,0 +1,153 @@
+package models
+
+// TimelineSlot 一个时钟周期内各处理机上运行的进程
+type TimelineSlot struct {
+	Tick       int    `json:"tick"`
+	Processors []int  `json:"processors"`          // 下标为处理机编号，值为PID，-1表示空闲
+	Switching  []bool `json:"switching,omitempty"` // 下标为处理机编号，为true表示该处理机在做上下文切换
+}
+
+// 时间线事件类型
+const (
+	TimelineProcessors      = "processors"       // 处理机数量变化
+	TimelineDeadlineMiss    = "deadline_miss"    // 实时作业错过截止时刻
+	TimelineLockWait        = "lock_wait"        // 进程因锁被其他进程持有而阻塞
+	TimelinePriorityBoost   = "priority_boost"   // 锁协议提高了持有锁的进程的有效优先数
+	TimelinePriorityRestore = "priority_restore" // 进程释放锁后恢复基础优先数
+)
+
+// TimelineEvent 时间线上发生在某个时钟周期开始前的系统事件
+type TimelineEvent struct {
+	Tick    int    `json:"tick"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	PIDs    []int  `json:"pids,omitempty"` // 受影响的进程
+}
+
+// ProcessStats 单个进程的调度指标
+type ProcessStats struct {
+	PID             int    `json:"pid"`
+	Name            string `json:"name"`
+	Arrival         int    `json:"arrival"`
+	Start           int    `json:"start"` // -1表示尚未运行
+	Finish          int    `json:"finish"`
+	Burst           int    `json:"burst"`
+	CPUTime         int    `json:"cpuTime"`
+	Turnaround      int    `json:"turnaround"` // 周转时间，仅对已完成进程有效
+	Waiting         int    `json:"waiting"`    // 等待时间，仅对已完成进程有效
+	Response        int    `json:"response"`   // 响应时间，仅对已运行过的进程有效
+	Finished        bool   `json:"finished"`
+	Migrations      int    `json:"migrations"`      // 换到另一个处理机上运行的次数
+	MaxWait         int    `json:"maxWait"`         // 在就绪或后备队列中最长的一次连续等待
+	LockWait        int    `json:"lockWait"`        // 因等待锁阻塞的时钟周期数
+	ContextSwitches int    `json:"contextSwitches"` // 处理机从其他进程切换到该进程的次数
+}
+
+// ProcessorStats 单个处理机的使用情况
+type ProcessorStats struct {
+	ID                   int     `json:"id"`
+	Speed                float64 `json:"speed"`
+	Type                 string  `json:"type,omitempty"`
+	BusyTicks            int     `json:"busyTicks"`            // 有进程运行的时钟周期数
+	Ticks                int     `json:"ticks"`                // 该处理机存在的时钟周期数
+	Utilization          float64 `json:"utilization"`          // 忙碌的时间占比
+	Switches             int     `json:"switches"`             // 上下文切换次数
+	OverheadTicks        int     `json:"overheadTicks"`        // 用于上下文切换的时钟周期数，计入忙碌时间
+	EffectiveUtilization float64 `json:"effectiveUtilization"` // 扣除上下文切换开销后的忙碌时间占比
+}
+
+// GangStats 进程组的调度指标
+type GangStats struct {
+	Name       string `json:"name"`
+	PIDs       []int  `json:"pids"`
+	Finished   bool   `json:"finished"`   // 组内进程是否都已完成
+	Finish     int    `json:"finish"`     // 最后一个进程的完成时刻
+	Turnaround int    `json:"turnaround"` // 从最早到达到最后完成的时间，仅对已完成的组有效
+}
+
+// GroupStats 公平份额调度中一个用户组的处理机使用情况
+type GroupStats struct {
+	Name          string  `json:"name"`
+	Shares        int     `json:"shares"`        // 配置的份额
+	Processes     int     `json:"processes"`     // 组内进程数
+	Finished      int     `json:"finished"`      // 组内已完成的进程数
+	CPUTime       int     `json:"cpuTime"`       // 组内进程占用处理机的时钟周期数之和
+	Usage         float64 `json:"usage"`         // 占所有进程处理机时间的比例
+	ExpectedShare float64 `json:"expectedShare"` // 份额占有进程的各组份额之和的比例
+}
+
+// UserStats 一个用户的处理机使用情况
+type UserStats struct {
+	Name      string  `json:"name"`
+	Processes int     `json:"processes"`
+	Finished  int     `json:"finished"`
+	CPUTime   int     `json:"cpuTime"`
+	Usage     float64 `json:"usage"` // 占所有进程处理机时间的比例
+}
+
+// Stats 系统的调度统计
+type Stats struct {
+	Clock                int              `json:"clock"`
+	Processes            int              `json:"processes"` // 进程总数
+	Finished             int              `json:"finished"`  // 已完成进程数
+	AvgWaiting           float64          `json:"avgWaiting"`
+	AvgTurnaround        float64          `json:"avgTurnaround"`
+	AvgResponse          float64          `json:"avgResponse"`
+	Throughput           float64          `json:"throughput"`           // 每个时钟周期完成的进程数
+	CPUUtilization       float64          `json:"cpuUtilization"`       // 处理机忙碌的时间占比
+	Migrations           int              `json:"migrations"`           // 所有进程的迁移次数之和
+	MaxWait              int              `json:"maxWait"`              // 所有进程中最长的一次连续等待
+	ContextSwitches      int              `json:"contextSwitches"`      // 所有处理机的上下文切换次数之和
+	OverheadTicks        int              `json:"overheadTicks"`        // 所有处理机用于上下文切换的时钟周期数
+	EffectiveUtilization float64          `json:"effectiveUtilization"` // 扣除上下文切换开销后的处理机利用率
+	PerProcess           []ProcessStats   `json:"perProcess"`
+	PerProcessor         []ProcessorStats `json:"perProcessor"`
+	Gangs                []GangStats      `json:"gangs,omitempty"`
+	DeadlineMisses       int              `json:"deadlineMisses"` // 错过截止时刻的实时作业数
+	Tasks                []TaskStats      `json:"tasks,omitempty"`
+	Groups               []GroupStats     `json:"groups"` // 各用户组的处理机使用情况，按组名排序
+	Users                []UserStats      `json:"users"`  // 各用户的处理机使用情况，按用户名排序
+}
+
+// SimulationResult 一次完整模拟的结果
+type SimulationResult struct {
+	Workload string          `json:"workload"`
+	Config   SystemConfig    `json:"config"`
+	Mapping  map[string]int  `json:"mapping"` // 负载内ID到PID的映射
+	Stats    *Stats          `json:"stats"`
+	Timeline []TimelineSlot  `json:"timeline"`
+	Events   []TimelineEvent `json:"events,omitempty"`
+}
+
+// ComparisonRow 一种调度策略与内存分配算法组合在同一负载上的运行结果
+type ComparisonRow struct {
+	Policy               SchedulingPolicy    `json:"policy"`
+	Allocator            AllocationAlgorithm `json:"allocator"`
+	Makespan             int                 `json:"makespan"` // 所有进程完成所用的时钟周期数
+	AvgWaiting           float64             `json:"avgWaiting"`
+	AvgTurnaround        float64             `json:"avgTurnaround"`
+	AvgResponse          float64             `json:"avgResponse"`
+	Throughput           float64             `json:"throughput"`
+	CPUUtilization       float64             `json:"cpuUtilization"`
+	ContextSwitches      int                 `json:"contextSwitches"`
+	EffectiveUtilization float64             `json:"effectiveUtilization"` // 扣除上下文切换开销后的处理机利用率
+	Error                string              `json:"error,omitempty"`      // 模拟失败的原因
+}
+
+// ProcessShare 单个进程实际获得与按彩票数应得的处理机时间
+type ProcessShare struct {
+	PID           int     `json:"pid"`
+	Name          string  `json:"name"`
+	Tickets       int     `json:"tickets"`
+	CPUTime       int     `json:"cpuTime"`       // 实际占用处理机的时钟周期数
+	Entitled      float64 `json:"entitled"`      // 按彩票数应得的时钟周期数
+	AchievedShare float64 `json:"achievedShare"` // 实际获得的处理机时间占所有进程的比例
+	ExpectedShare float64 `json:"expectedShare"` // 应得的处理机时间占所有进程的比例
+	Ratio         float64 `json:"ratio"`         // 实际与应得之比，1表示恰好得到应得的份额
+}
+
+// ShareReport 比例份额报告
+type ShareReport struct {
+	Clock     int            `json:"clock"`
+	Processes []ProcessShare `json:"processes"`
+}
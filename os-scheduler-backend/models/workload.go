@@ -0,0 +1,24 @@
+package models
+
+// Workload 负载描述：一组带到达时间和依赖关系的进程
+type Workload struct {
+	Name      string            `json:"name"`
+	Processes []WorkloadProcess `json:"processes"`
+}
+
+// WorkloadProcess 负载中的单个进程
+type WorkloadProcess struct {
+	ID               string            `json:"id"` // 负载内ID，为空时使用进程名
+	Name             string            `json:"name"`
+	Arrival          int               `json:"arrival"`                    // 相对负载提交时刻的到达时间
+	Burst            int               `json:"burst"`                      // 运行时间
+	Priority         int               `json:"priority"`                   // 优先数
+	Memory           int               `json:"memory"`                     // 内存大小
+	Predecessors     []string          `json:"predecessors"`               // 前驱进程的负载内ID
+	Affinity         uint64            `json:"affinity,omitempty"`         // 允许运行的处理机位掩码，0表示不限制
+	Gang             string            `json:"gang,omitempty"`             // 所属进程组，组内进程同时运行
+	Tickets          int               `json:"tickets,omitempty"`          // 比例份额调度的彩票数，0表示默认值
+	User             string            `json:"user,omitempty"`             // 所属用户，为空表示默认用户
+	Group            string            `json:"group,omitempty"`            // 所属用户组，为空表示默认组
+	CriticalSections []CriticalSection `json:"criticalSections,omitempty"` // 临界区
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os-scheduler-backend/models"
+)
+
+// DefaultBalanceInterval 独立就绪队列之间周期性负载均衡的默认间隔
+const DefaultBalanceInterval = 4
+
+// allowedOn 判断进程的亲和性掩码是否允许它在处理机cpu上运行
+func allowedOn(p *models.PCB, cpu int) bool {
+	return p.AffinityMask == 0 || cpu < 64 && p.AffinityMask&(1<<uint(cpu)) != 0
+}
+
+// SetAffinity 设置进程的亲和性掩码，第i位为1表示允许在处理机i上运行，0表示不限制。
+// 正在运行的进程若不再允许在当前处理机上运行，下一次调度时让出处理机
+func (s *Scheduler) SetAffinity(pid int, mask uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	p := s.findProcess(pid)
+	if p == nil {
+		return fmt.Errorf("找不到进程 %d", pid)
+	}
+	if p.State == models.Finished {
+		return fmt.Errorf("进程 %d 已完成", pid)
+	}
+	if !s.anyProcessorAllowed(mask) {
+		return errors.New("亲和性掩码不包含任何现有处理机")
+	}
+	previous := p.AffinityMask
+	p.AffinityMask = mask
+	if p.Gang != "" {
+		if err := s.checkGangs(nil, s.ProcessorCount, s.MaxProcesses); err != nil {
+			p.AffinityMask = previous
+			return err
+		}
+	}
+	s.eventLog.Record(OpSetAffinity, affinityArgs{PID: pid, Mask: mask})
+	return nil
+}
+
+// anyProcessorAllowed 判断掩码是否允许在至少一个现有处理机上运行
+func (s *Scheduler) anyProcessorAllowed(mask uint64) bool {
+	return allowedWithin(mask, s.ProcessorCount)
+}
+
+// allowedWithin 判断掩码是否允许在编号小于count的至少一个处理机上运行
+func allowedWithin(mask uint64, count int) bool {
+	p := &models.PCB{AffinityMask: mask}
+	for cpu := 0; cpu < count; cpu++ {
+		if allowedOn(p, cpu) {
+			return true
+		}
+	}
+	return false
+}
+
+// runOn 让进程在处理机cpu上运行，换了处理机时计入一次迁移并产生迁移开销。
+// 进程不能转为运行状态时返回错误，调用者应把进程留在就绪队列中
+func (s *Scheduler) runOn(p *models.PCB, cpu int) error {
+	if err := s.transition(p, models.Running, s.Clock, fmt.Sprintf("获得处理机 %d", cpu)); err != nil {
+		return err
+	}
+	if p.LastProcessor >= 0 && p.LastProcessor != cpu {
+		p.Migrations++
+		p.StallTicks = s.MigrationCost
+	}
+	s.chargeSwitch(p, cpu)
+	p.WaitTicks = 0
+	p.ProcessorID = cpu
+	p.LastProcessor = cpu
+	p.RunQueue = cpu
+	if p.StartTime < 0 {
+		p.StartTime = s.Clock
+	}
+	s.Queue.Running = append(s.Queue.Running, p)
+	return nil
+}
+
+// pickProcessor 为进程挑选允许的最快的空闲处理机，速度相同时优先使用进程上次运行的处理机，
+// 没有时返回-1
+func (s *Scheduler) pickProcessor(p *models.PCB, busy []bool) int {
+	cpu := -1
+	if p.LastProcessor >= 0 && p.LastProcessor < len(busy) && !busy[p.LastProcessor] && allowedOn(p, p.LastProcessor) {
+		cpu = p.LastProcessor
+	}
+	for i := range busy {
+		if !busy[i] && allowedOn(p, i) && (cpu < 0 || s.faster(i, cpu)) {
+			cpu = i
+		}
+	}
+	return cpu
+}
+
+// assignGlobal 所有处理机共用一个就绪队列：按队列顺序为进程挑选处理机，
+// 进程组在组内排得最靠前的进程的位置上整体分配，没有可用处理机的进程留在就绪队列中
+func (s *Scheduler) assignGlobal(busy []bool) {
+	handled := make(map[string]bool)
+	for _, p := range append([]*models.PCB(nil), s.Queue.Ready...) {
+		if p.State != models.Ready {
+			continue
+		}
+		if p.Gang != "" {
+			if !handled[p.Gang] {
+				handled[p.Gang] = true
+				s.dispatchGang(p.Gang, busy)
+			}
+			continue
+		}
+		if cpu := s.pickProcessor(p, busy); cpu >= 0 && s.runOn(p, cpu) == nil {
+			busy[cpu] = true
+			s.removeFromReady(p)
+		}
+	}
+}
+
+// assignPerProcessor 每个处理机使用独立的就绪队列：空闲处理机取自己队列中最靠前的进程，
+// 自己的队列为空时从处理机正忙的最长队列中拉取一个允许在本处理机上运行的进程。
+// 进程组不进入各处理机的队列，先于其他进程整体分配
+func (s *Scheduler) assignPerProcessor(busy []bool) {
+	s.dispatchGangs(busy)
+	loads := s.balance()
+	wasBusy := append([]bool(nil), busy...)
+
+	for cpu := range busy {
+		if busy[cpu] {
+			continue
+		}
+		index := -1
+		for i, p := range s.Queue.Ready {
+			if p.RunQueue == cpu && p.Gang == "" {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			index = s.pullIndex(cpu, loads, wasBusy)
+		}
+		if index < 0 {
+			continue
+		}
+
+		p := s.Queue.Ready[index]
+		queue := p.RunQueue
+		if s.runOn(p, cpu) != nil {
+			continue
+		}
+		s.Queue.Ready = append(s.Queue.Ready[:index], s.Queue.Ready[index+1:]...)
+		if queue != cpu {
+			loads[queue]--
+			loads[cpu]++
+		}
+		busy[cpu] = true
+	}
+}
+
+// pullIndex 返回空闲处理机cpu可以拉取的就绪进程下标，没有时返回-1。
+// 只从处理机正忙的队列中拉取，空闲处理机的队列由它自己处理
+func (s *Scheduler) pullIndex(cpu int, loads []int, busy []bool) int {
+	index, busiest := -1, 0
+	for i, p := range s.Queue.Ready {
+		q := p.RunQueue
+		if p.Gang != "" || q < 0 || q == cpu || !busy[q] || !allowedOn(p, cpu) {
+			continue
+		}
+		if index < 0 || loads[q] > busiest {
+			index, busiest = i, loads[q]
+		}
+	}
+	return index
+}
+
+// balance 把尚未分配队列的就绪进程放入队列，并在均衡周期到达时把进程从最长的队列推到最短的队列。
+// 返回各队列中就绪和运行中的进程数
+func (s *Scheduler) balance() []int {
+	loads := make([]int, s.ProcessorCount)
+	for _, p := range s.Queue.Running {
+		loads[p.ProcessorID]++
+	}
+	unplaced := make([]*models.PCB, 0)
+	for _, p := range s.Queue.Ready {
+		if p.Gang != "" {
+			continue
+		}
+		if p.RunQueue >= 0 && p.RunQueue < s.ProcessorCount && allowedOn(p, p.RunQueue) {
+			loads[p.RunQueue]++
+		} else {
+			unplaced = append(unplaced, p)
+		}
+	}
+
+	// 新就绪的进程优先回到上次运行的处理机，否则放入按速度折算后最短的队列
+	for _, p := range unplaced {
+		p.RunQueue = -1
+		if p.LastProcessor >= 0 && p.LastProcessor < s.ProcessorCount && allowedOn(p, p.LastProcessor) {
+			p.RunQueue = p.LastProcessor
+		} else {
+			for cpu := range loads {
+				if allowedOn(p, cpu) && (p.RunQueue < 0 || s.lighter(cpu, p.RunQueue, loads)) {
+					p.RunQueue = cpu
+				}
+			}
+		}
+		if p.RunQueue >= 0 {
+			loads[p.RunQueue]++
+		}
+	}
+
+	if s.BalanceInterval > 0 && s.Clock%s.BalanceInterval == 0 {
+		for s.push(loads) {
+		}
+	}
+	return loads
+}
+
+// lighter 判断再加入一个进程后，处理机a的队列按速度折算的负载是否比处理机b轻
+func (s *Scheduler) lighter(a, b int, loads []int) bool {
+	return (loads[a]+1)*s.speed(b) < (loads[b]+1)*s.speed(a)
+}
+
+// push 把最长队列中最靠后的可迁移进程推到最短的队列，队列长度相差不超过1或没有可迁移的进程时返回false
+func (s *Scheduler) push(loads []int) bool {
+	busiest, idlest := 0, 0
+	for cpu := range loads {
+		if loads[cpu] > loads[busiest] {
+			busiest = cpu
+		}
+		if loads[cpu] < loads[idlest] {
+			idlest = cpu
+		}
+	}
+	if loads[busiest]-loads[idlest] <= 1 {
+		return false
+	}
+
+	for i := len(s.Queue.Ready) - 1; i >= 0; i-- {
+		p := s.Queue.Ready[i]
+		if p.RunQueue == busiest && p.Gang == "" && allowedOn(p, idlest) {
+			p.RunQueue = idlest
+			loads[busiest]--
+			loads[idlest]++
+			return true
+		}
+	}
+	return false
+}
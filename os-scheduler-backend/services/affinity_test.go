@@ -0,0 +1,54 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"strings"
+	"testing"
+)
+
+func TestSubmitRejectsAffinityOutsideProcessors(t *testing.T) {
+	s := newTestSystem(t, nil)
+	const mask = 0b1000
+
+	if err := s.AddProcess(&models.PCB{Name: "p", RequiredTime: 2, AffinityMask: mask}); err == nil {
+		t.Error("AddProcess accepted a mask with no existing processor")
+	}
+	if _, _, err := s.AddProcessBatch([]models.BatchProcess{{ID: "p", RequiredTime: 2, AffinityMask: mask}}); err == nil {
+		t.Error("AddProcessBatch accepted a mask with no existing processor")
+	}
+	_, err := s.SubmitWorkload(&models.Workload{Processes: []models.WorkloadProcess{{ID: "p", Burst: 2, Affinity: mask}}})
+	if err == nil || !strings.Contains(err.Error(), "亲和性") {
+		t.Errorf("SubmitWorkload error = %v, want an affinity error", err)
+	}
+	if n := len(s.allProcesses()); n != 0 {
+		t.Errorf("%d processes added", n)
+	}
+
+	// 掩码中只要有一个现有处理机即可
+	addTestProcess(t, s, &models.PCB{Name: "q", RequiredTime: 2, AffinityMask: 0b1010})
+}
+
+func TestShrinkKeepsPinnedProcessesRunnable(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.ProcessorCount = 2 })
+	pinned := addTestProcess(t, s, &models.PCB{Name: "pinned", RequiredTime: 2, AffinityMask: 0b10})
+
+	if err := s.SetProcessorCount(1); err == nil {
+		t.Fatal("SetProcessorCount(1) stranded a process pinned to CPU1")
+	}
+	cfg := s.Config()
+	cfg.ProcessorCount = 1
+	if err := s.Configure(cfg, false); err == nil {
+		t.Fatal("Configure stranded a process pinned to CPU1")
+	}
+	if s.ProcessorCount != 2 {
+		t.Fatalf("processor count = %d after rejected shrink", s.ProcessorCount)
+	}
+
+	// 固定在处理机1上的进程完成后可以减少处理机
+	for s.findProcess(pinned).State != models.Finished {
+		s.Schedule()
+	}
+	if err := s.SetProcessorCount(1); err != nil {
+		t.Fatalf("SetProcessorCount(1) after the pinned process finished: %v", err)
+	}
+}
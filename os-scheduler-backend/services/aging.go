@@ -0,0 +1,49 @@
+package services
+
+import "os-scheduler-backend/models"
+
+// DefaultAgingStep 每次老化默认增加的优先数
+const DefaultAgingStep = 1
+
+// age 在时钟周期结束时累计就绪和后备队列中进程的连续等待时间。
+// 动态优先数策略下开启老化时，进程每连续等待 AgingInterval 个时钟周期优先数增加 AgingStep，
+// 后备队列中的进程只在 AgeBackup 为true时老化
+func (s *Scheduler) age() {
+	aging := s.Policy == models.PolicyPriority && s.AgingInterval > 0
+	for _, p := range s.Queue.Ready {
+		s.wait(p, aging)
+	}
+	for _, p := range s.Queue.Backup {
+		s.wait(p, aging && s.AgeBackup)
+	}
+}
+
+func (s *Scheduler) wait(p *models.PCB, aging bool) {
+	p.WaitTicks++
+	if p.WaitTicks > p.MaxWait {
+		p.MaxWait = p.WaitTicks
+	}
+	if aging && p.WaitTicks%s.AgingInterval == 0 {
+		p.Priority += s.AgingStep
+	}
+}
+
+// nextBackup 返回下一个调入的后备进程的下标，跳过skipped中的进程，没有时返回-1：默认先来先调入；
+// 动态优先数策略下后备进程参与老化时调入优先数最大的进程，相同时先来先调入
+func (s *Scheduler) nextBackup(skipped map[int]bool) int {
+	next := -1
+	for i, p := range s.Queue.Backup {
+		if skipped[p.PID] {
+			continue
+		}
+		if next < 0 {
+			next = i
+			if s.Policy != models.PolicyPriority || !s.AgeBackup {
+				break
+			}
+		} else if p.Priority > s.Queue.Backup[next].Priority {
+			next = i
+		}
+	}
+	return next
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"testing"
+)
+
+// starvationSystem 创建单处理机的动态优先数系统，添加一个高优先数的长作业和一个低优先数的短作业，
+// 返回短作业
+func starvationSystem(t *testing.T, interval int) (*Scheduler, *models.PCB) {
+	t.Helper()
+	s := newTestSystem(t, func(cfg *models.SystemConfig) {
+		cfg.ProcessorCount = 1
+		cfg.Policy = models.PolicyPriority
+		cfg.AgingInterval = interval
+		cfg.AgingStep = 2
+	})
+	addTestProcess(t, s, &models.PCB{Name: "hog", RequiredTime: 100, Priority: 40})
+	low := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "low", RequiredTime: 1, Priority: 0}))
+	return s, low
+}
+
+func TestAgingShortensStarvation(t *testing.T) {
+	s, starved := starvationSystem(t, 0)
+	runUntilDone(t, s, 200)
+	aged, low := starvationSystem(t, 1)
+	runUntilDone(t, aged, 200)
+
+	// 不老化时要等到长作业的优先数降到0以下；老化后等待时间约缩短为三分之一
+	if starved.MaxWait < 40 {
+		t.Fatalf("without aging the low process waited %d ticks, want at least 40", starved.MaxWait)
+	}
+	if low.MaxWait > starved.MaxWait/2 {
+		t.Errorf("with aging the low process waited %d ticks, without %d", low.MaxWait, starved.MaxWait)
+	}
+	if got := aged.Stats().MaxWait; got != low.MaxWait {
+		t.Errorf("system max wait = %d, want %d", got, low.MaxWait)
+	}
+}
+
+func TestAgingOnlyAppliesToPriorityPolicy(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) {
+		cfg.ProcessorCount = 1
+		cfg.Policy = models.PolicyFCFS
+		cfg.AgingInterval = 1
+	})
+	addTestProcess(t, s, &models.PCB{Name: "first", RequiredTime: 5})
+	second := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "second", RequiredTime: 1, Priority: 3}))
+	for i := 0; i < 4; i++ {
+		s.Schedule()
+	}
+	if second.Priority != 3 {
+		t.Errorf("priority = %d under fcfs, want 3", second.Priority)
+	}
+	if second.WaitTicks != 3 || second.MaxWait != 3 {
+		t.Errorf("waitTicks=%d maxWait=%d, want 3 and 3", second.WaitTicks, second.MaxWait)
+	}
+	runUntilDone(t, s, 20)
+	if second.WaitTicks != 0 || second.MaxWait != 5 {
+		t.Errorf("after running: waitTicks=%d maxWait=%d, want 0 and 5", second.WaitTicks, second.MaxWait)
+	}
+}
+
+func TestAgeBackupAdmitsByPriority(t *testing.T) {
+	for _, ageBackup := range []bool{false, true} {
+		s := newTestSystem(t, func(cfg *models.SystemConfig) {
+			cfg.ProcessorCount = 1
+			cfg.MaxProcesses = 1
+			cfg.Policy = models.PolicyPriority
+			cfg.AgingInterval = 10
+			cfg.AgeBackup = ageBackup
+		})
+		running := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "running", RequiredTime: 2}))
+		low := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "low", RequiredTime: 2, Priority: 1}))
+		high := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "high", RequiredTime: 2, Priority: 5}))
+		for running.State != models.Finished {
+			s.Schedule()
+		}
+		// 不老化后备队列时先来先调入，否则按优先数调入
+		admitted, waiting := low, high
+		if ageBackup {
+			admitted, waiting = high, low
+		}
+		if len(s.Queue.Backup) != 1 || s.Queue.Backup[0] != waiting {
+			t.Errorf("ageBackup=%v: %s was admitted before %s", ageBackup, waiting.Name, admitted.Name)
+		}
+	}
+}
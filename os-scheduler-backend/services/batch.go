@@ -0,0 +1,164 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os-scheduler-backend/models"
+)
+
+// AddProcessBatch 原子地提交一组进程：先校验整个前驱图，再为所有进程分配内存，
+// 任一步骤失败时不改变系统状态。返回批内ID到PID的映射以及创建的进程
+func (s *Scheduler) AddProcessBatch(batch []models.BatchProcess) (map[string]int, []*models.PCB, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	mapping, processes, err := s.prepareBatch(batch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 分配内存，失败时释放本批次已分配的内存
+	for i, process := range processes {
+		start, err := s.memoryManager.allocate(process.MemorySize)
+		if err != nil {
+			for _, allocated := range processes[:i] {
+				s.memoryManager.free(allocated.MemoryStart)
+			}
+			return nil, nil, fmt.Errorf("进程 %s 内存分配失败: %w", batch[i].ID, err)
+		}
+		process.MemoryStart = start
+	}
+
+	s.nextPID += len(batch)
+	for _, process := range processes {
+		process.ArrivalTime = s.Clock
+	}
+	s.insertProcesses(topologicalOrder(processes))
+	s.eventLog.Record(OpAddBatch, addBatchArgs{Processes: batch, Mapping: mapping})
+	return mapping, processes, nil
+}
+
+// validateSpec 校验提交的进程：运行时间必须大于0，内存大小不能为负数或超过用户区内存，
+// 亲和性掩码必须允许在至少一个现有处理机上运行，彩票数不能为负数或超过上限
+func (s *Scheduler) validateSpec(id string, burst, memory int, mask uint64, tickets int) error {
+	userMemory := s.memoryManager.Memory.TotalSize - s.memoryManager.Memory.OSSize
+	if burst <= 0 {
+		return fmt.Errorf("进程 %s 的运行时间必须大于0", id)
+	}
+	if memory < 0 || memory > userMemory {
+		return fmt.Errorf("进程 %s 的内存大小必须在0到%d之间", id, userMemory)
+	}
+	if !s.anyProcessorAllowed(mask) {
+		return fmt.Errorf("进程 %s 的亲和性掩码不包含任何现有处理机", id)
+	}
+	if tickets < 0 || tickets > MaxTickets {
+		return fmt.Errorf("进程 %s 的彩票数必须在0到%d之间", id, MaxTickets)
+	}
+	return nil
+}
+
+// prepareBatch 按提交顺序为一组进程预分配PID、解析批内前驱引用并校验前驱图，
+// 不改变系统状态
+func (s *Scheduler) prepareBatch(batch []models.BatchProcess) (map[string]int, []*models.PCB, error) {
+	// 按提交顺序预分配PID，保证相同的请求得到相同的PID
+	mapping := make(map[string]int, len(batch))
+	localIDs := make(map[int]string, len(batch))
+	for i, spec := range batch {
+		if _, ok := mapping[spec.ID]; ok || spec.ID == "" {
+			return nil, nil, &PrecedenceError{Reason: ReasonDuplicateID, LocalID: spec.ID}
+		}
+		mapping[spec.ID] = s.nextPID + i
+		localIDs[s.nextPID+i] = spec.ID
+	}
+
+	processes := make([]*models.PCB, 0, len(batch))
+	for _, spec := range batch {
+		process := &models.PCB{
+			Name:              spec.Name,
+			PID:               mapping[spec.ID],
+			RequiredTime:      spec.RequiredTime,
+			TotalRequiredTime: spec.RequiredTime,
+			Priority:          spec.Priority,
+			InitialPriority:   spec.Priority,
+			MemorySize:        spec.MemorySize,
+			AffinityMask:      spec.AffinityMask,
+			Gang:              spec.Gang,
+			Tickets:           spec.Tickets,
+			User:              spec.User,
+			Group:             spec.Group,
+			CriticalSections:  append([]models.CriticalSection(nil), spec.CriticalSections...),
+			StartTime:         -1,
+			ProcessorID:       -1,
+			LastProcessor:     -1,
+			RunQueue:          -1,
+			Predecessors:      make([]int, 0, len(spec.Predecessors)+len(spec.PredecessorPIDs)),
+		}
+		for _, localPred := range spec.Predecessors {
+			predPID, ok := mapping[localPred]
+			if !ok {
+				return nil, nil, &PrecedenceError{
+					Reason:           ReasonUnknownPredecessor,
+					PID:              process.PID,
+					LocalID:          spec.ID,
+					LocalPredecessor: localPred,
+				}
+			}
+			process.Predecessors = append(process.Predecessors, predPID)
+		}
+		process.Predecessors = uniquePIDs(append(process.Predecessors, spec.PredecessorPIDs...))
+		if err := s.validateSpec(spec.ID, spec.RequiredTime, spec.MemorySize, spec.AffinityMask, spec.Tickets); err != nil {
+			return nil, nil, err
+		}
+		if err := validateSections(spec.ID, process.CriticalSections, spec.RequiredTime); err != nil {
+			return nil, nil, err
+		}
+		processes = append(processes, process)
+	}
+
+	if err := s.validatePrecedence(processes); err != nil {
+		var precedenceErr *PrecedenceError
+		if errors.As(err, &precedenceErr) {
+			precedenceErr.LocalID = localIDs[precedenceErr.PID]
+			precedenceErr.LocalPredecessor = localIDs[precedenceErr.Predecessor]
+			for _, pid := range precedenceErr.Cycle {
+				precedenceErr.LocalCycle = append(precedenceErr.LocalCycle, localIDs[pid])
+			}
+		}
+		return nil, nil, err
+	}
+	if err := s.checkNewGangs(processes); err != nil {
+		return nil, nil, err
+	}
+	return mapping, processes, nil
+}
+
+// topologicalOrder 按前驱关系对同批进程排序，使前驱先于后继入队；
+// 没有依赖关系的进程保持提交顺序
+func topologicalOrder(processes []*models.PCB) []*models.PCB {
+	inBatch := make(map[int]bool, len(processes))
+	for _, p := range processes {
+		inBatch[p.PID] = true
+	}
+
+	placed := make(map[int]bool, len(processes))
+	ordered := make([]*models.PCB, 0, len(processes))
+	for len(ordered) < len(processes) {
+		for _, p := range processes {
+			if placed[p.PID] {
+				continue
+			}
+			ready := true
+			for _, predPID := range p.Predecessors {
+				if inBatch[predPID] && !placed[predPID] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				placed[p.PID] = true
+				ordered = append(ordered, p)
+			}
+		}
+	}
+	return ordered
+}
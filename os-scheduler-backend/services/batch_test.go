@@ -0,0 +1,48 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"strings"
+	"testing"
+)
+
+func TestBatchAndWorkloadRejectInvalidSpecs(t *testing.T) {
+	cases := []struct {
+		name   string
+		burst  int
+		memory int
+		want   string
+	}{
+		{"zero burst", 0, 10, "运行时间必须大于0"},
+		{"negative burst", -1, 10, "运行时间必须大于0"},
+		{"negative memory", 3, -1, "内存大小必须在0到"},
+		{"too much memory", 3, 1 << 20, "内存大小必须在0到"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestSystem(t, nil)
+			_, _, err := s.AddProcessBatch([]models.BatchProcess{
+				{ID: "ok", Name: "ok", RequiredTime: 2, MemorySize: 10},
+				{ID: "bad", Name: "bad", RequiredTime: tc.burst, MemorySize: tc.memory},
+			})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("AddProcessBatch error = %v, want %q", err, tc.want)
+			}
+
+			_, err = s.SubmitWorkload(&models.Workload{Processes: []models.WorkloadProcess{
+				{ID: "ok", Name: "ok", Burst: 2, Memory: 10},
+				{ID: "bad", Name: "bad", Burst: tc.burst, Memory: tc.memory},
+			}})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("SubmitWorkload error = %v, want %q", err, tc.want)
+			}
+
+			if n := len(s.allProcesses()); n != 0 {
+				t.Errorf("%d processes added after rejected submissions", n)
+			}
+			if s.nextPID != 1 {
+				t.Errorf("nextPID = %d, want 1", s.nextPID)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"container/heap"
+	"os-scheduler-backend/models"
+)
+
+// 完全公平调度的默认参数，单位为时钟周期
+const (
+	DefaultTargetLatency  = 6
+	DefaultMinGranularity = 1
+)
+
+// nice0Weight nice值为0的进程的权重
+const nice0Weight = 1024
+
+// niceWeights nice值从-20到19对应的权重，相邻两级相差约1.25倍（与Linux相同）
+var niceWeights = [40]int{
+	/* -20 */ 88761, 71755, 56483, 46273, 36291,
+	/* -15 */ 29154, 23254, 18705, 14949, 11916,
+	/* -10 */ 9548, 7620, 6100, 4904, 3906,
+	/*  -5 */ 3121, 2501, 1991, 1586, 1277,
+	/*   0 */ 1024, 820, 655, 526, 423,
+	/*   5 */ 335, 272, 215, 172, 137,
+	/*  10 */ 110, 87, 70, 56, 45,
+	/*  15 */ 36, 29, 23, 18, 15,
+}
+
+// nice 由优先数得到nice值：优先数越大nice值越小，限制在-20到19之间
+func nice(p *models.PCB) int {
+	n := -p.Priority
+	if n < -20 {
+		n = -20
+	}
+	if n > 19 {
+		n = 19
+	}
+	return n
+}
+
+// weight 返回进程的权重
+func weight(p *models.PCB) int {
+	return niceWeights[nice(p)+20]
+}
+
+// vruntimeDelta 返回进程运行一个时钟周期增加的虚拟运行时间
+func vruntimeDelta(p *models.PCB) int {
+	return 1000 * nice0Weight / weight(p)
+}
+
+// cfsSlice 返回进程本次占用处理机的时间片：调度周期按权重分给所有可运行进程，
+// 调度周期为目标延迟，可运行进程太多时延长为 进程数×最短时间，时间片不少于最短时间
+func (s *Scheduler) cfsSlice(p *models.PCB) int {
+	total, count := 0, 0
+	for _, q := range s.Queue.Running {
+		total += weight(q)
+		count++
+	}
+	for _, q := range s.Queue.Ready {
+		total += weight(q)
+		count++
+	}
+	period := s.TargetLatency
+	if count*s.MinGranularity > period {
+		period = count * s.MinGranularity
+	}
+	slice := (period*weight(p) + total - 1) / total
+	if slice < s.MinGranularity {
+		slice = s.MinGranularity
+	}
+	return slice
+}
+
+// cfsRunQueue 按虚拟运行时间排序的就绪进程最小堆，虚拟运行时间相同时PID小的优先。
+// 堆由就绪队列导出，不保存在快照中，恢复后在下一次调度时重建
+type cfsRunQueue struct {
+	items []*models.PCB
+	index map[int]int // PID到堆中下标的映射
+}
+
+func newCFSRunQueue() *cfsRunQueue {
+	return &cfsRunQueue{index: make(map[int]int)}
+}
+
+func (q *cfsRunQueue) Len() int { return len(q.items) }
+
+func (q *cfsRunQueue) Less(i, j int) bool {
+	if q.items[i].VRuntime != q.items[j].VRuntime {
+		return q.items[i].VRuntime < q.items[j].VRuntime
+	}
+	return q.items[i].PID < q.items[j].PID
+}
+
+func (q *cfsRunQueue) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.index[q.items[i].PID] = i
+	q.index[q.items[j].PID] = j
+}
+
+func (q *cfsRunQueue) Push(x interface{}) {
+	p := x.(*models.PCB)
+	q.index[p.PID] = len(q.items)
+	q.items = append(q.items, p)
+}
+
+func (q *cfsRunQueue) Pop() interface{} {
+	p := q.items[len(q.items)-1]
+	q.items = q.items[:len(q.items)-1]
+	delete(q.index, p.PID)
+	return p
+}
+
+// syncCFS 让运行队列与就绪队列一致：移除已离开就绪队列的进程，加入新就绪的进程。
+// 就绪进程不运行，虚拟运行时间不变，堆中的顺序一直有效
+func (s *Scheduler) syncCFS() {
+	if s.cfs == nil {
+		s.cfs = newCFSRunQueue()
+	}
+	ready := make(map[int]*models.PCB, len(s.Queue.Ready))
+	for _, p := range s.Queue.Ready {
+		ready[p.PID] = p
+	}
+	for i := s.cfs.Len() - 1; i >= 0; i-- {
+		if i < s.cfs.Len() && ready[s.cfs.items[i].PID] != s.cfs.items[i] {
+			heap.Remove(s.cfs, i)
+		}
+	}
+	for _, p := range s.Queue.Ready {
+		if _, ok := s.cfs.index[p.PID]; !ok {
+			heap.Push(s.cfs, p)
+		}
+	}
+}
+
+// placeVRuntime 虚拟运行时间落后于最小值的就绪进程（新到达或刚恢复的进程）从最小值开始，
+// 不能凭不可运行期间落后的虚拟运行时间长期独占处理机；然后更新可运行进程的最小虚拟运行时间，
+// 最小值只增不减，堆中的进程都不小于它
+func (s *Scheduler) placeVRuntime() {
+	for _, p := range s.Queue.Ready {
+		if p.VRuntime < s.MinVRuntime {
+			p.VRuntime = s.MinVRuntime
+		}
+	}
+	min := -1
+	for _, p := range append(append([]*models.PCB(nil), s.Queue.Running...), s.Queue.Ready...) {
+		if min < 0 || p.VRuntime < min {
+			min = p.VRuntime
+		}
+	}
+	if min > s.MinVRuntime {
+		s.MinVRuntime = min
+	}
+}
+
+// assignCFS 完全公平调度：依次从堆中取出虚拟运行时间最小的进程分配空闲处理机，
+// 没有允许的空闲处理机的进程放回堆中
+func (s *Scheduler) assignCFS(busy []bool) {
+	s.placeVRuntime()
+	s.syncCFS()
+
+	skipped := make([]*models.PCB, 0)
+	handled := make(map[string]bool)
+	for s.cfs.Len() > 0 && anyFree(busy) {
+		p := heap.Pop(s.cfs).(*models.PCB)
+		if p.Gang != "" {
+			if !handled[p.Gang] {
+				handled[p.Gang] = true
+				s.dispatchGang(p.Gang, busy)
+			}
+			if p.State != models.Running {
+				skipped = append(skipped, p)
+			}
+			continue
+		}
+		cpu := s.pickProcessor(p, busy)
+		if cpu < 0 || s.runOn(p, cpu) != nil {
+			skipped = append(skipped, p)
+			continue
+		}
+		busy[cpu] = true
+		s.removeFromReady(p)
+	}
+	for _, p := range skipped {
+		heap.Push(s.cfs, p)
+	}
+}
+
+// anyFree 判断是否还有空闲处理机
+func anyFree(busy []bool) bool {
+	for _, b := range busy {
+		if !b {
+			return true
+		}
+	}
+	return false
+}
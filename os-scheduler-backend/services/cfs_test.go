@@ -0,0 +1,85 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"testing"
+)
+
+// cfsSystem 创建单处理机、完全公平调度的系统
+func cfsSystem(t *testing.T) *Scheduler {
+	t.Helper()
+	return newTestSystem(t, func(cfg *models.SystemConfig) {
+		cfg.ProcessorCount = 1
+		cfg.Policy = models.PolicyCFS
+	})
+}
+
+func TestCFSSharesByWeight(t *testing.T) {
+	s := cfsSystem(t)
+	heavy := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "heavy", RequiredTime: 1000, Priority: 5}))
+	light := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "light", RequiredTime: 1000}))
+	for i := 0; i < 401; i++ {
+		s.Schedule()
+	}
+	// nice -5 与 nice 0 的权重之比为 3121:1024
+	share := float64(heavy.CPUTime) / 400
+	if share < 0.72 || share > 0.78 {
+		t.Errorf("heavy process got %.3f of the processor, want about 0.75", share)
+	}
+	// 虚拟运行时间保持接近，相差不超过一个时间片
+	diff := heavy.VRuntime - light.VRuntime
+	if diff < 0 {
+		diff = -diff
+	}
+	if limit := DefaultTargetLatency * vruntimeDelta(light); diff > limit {
+		t.Errorf("vruntime differs by %d, want at most %d", diff, limit)
+	}
+}
+
+func TestCFSEqualWeightsAlternateBySlice(t *testing.T) {
+	s := cfsSystem(t)
+	a := addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 100})
+	b := addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 100})
+	for i := 0; i < 13; i++ {
+		s.Schedule()
+	}
+	// 目标延迟6由两个进程平分，每次运行3个时钟周期
+	want := []int{a, a, a, b, b, b, a, a, a, b, b, b}
+	for i, slot := range s.Timeline {
+		if slot.Processors[0] != want[i] {
+			t.Fatalf("tick %d ran %d, want %d (timeline %v)", i, slot.Processors[0], want[i], s.Timeline)
+		}
+	}
+}
+
+func TestCFSLateArrivalStartsAtMinVRuntime(t *testing.T) {
+	s := cfsSystem(t)
+	a := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 1000}))
+	for i := 0; i < 101; i++ {
+		s.Schedule()
+	}
+	late := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "late", RequiredTime: 1000}))
+	before := a.CPUTime
+	for i := 0; i < 60; i++ {
+		s.Schedule()
+	}
+	// 新到达的进程不能凭虚拟运行时间为0独占处理机
+	if ran := a.CPUTime - before; ran < 25 {
+		t.Errorf("running process got %d of 60 ticks after a late arrival, want about 30", ran)
+	}
+	if late.CPUTime < 25 {
+		t.Errorf("late process got %d of 60 ticks, want about 30", late.CPUTime)
+	}
+}
+
+func TestNiceClampsPriorities(t *testing.T) {
+	cases := map[int]int{-50: 19, -19: 19, 0: 0, 5: -5, 20: -20, 99: -20}
+	for priority, want := range cases {
+		if got := nice(&models.PCB{Priority: priority}); got != want {
+			t.Errorf("nice(priority %d) = %d, want %d", priority, got, want)
+		}
+	}
+	if weight(&models.PCB{}) != nice0Weight {
+		t.Errorf("weight of priority 0 = %d, want %d", weight(&models.PCB{}), nice0Weight)
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"fmt"
+	"os-scheduler-backend/models"
+)
+
+// MaxCompareTicks 对比调度策略时每个组合最多模拟的时钟周期数
+const MaxCompareTicks = 20000
+
+// MaxComparisons 一次对比最多运行的策略与分配算法组合数
+const MaxComparisons = 20
+
+// ComparePolicies 对调度策略与内存分配算法的每种组合，在按base新建的独立调度器上运行同一负载。
+// policies或allocators为空时使用base中的设置，重复的策略或算法只运行一次；
+// 组合数超过 MaxComparisons 或maxTicks超过 MaxCompareTicks 时不运行任何组合。
+// 某个组合模拟失败时在对应行记录原因
+func ComparePolicies(workload *models.Workload, base models.SystemConfig, policies []models.SchedulingPolicy, allocators []models.AllocationAlgorithm, maxTicks int) ([]models.ComparisonRow, error) {
+	if len(policies) == 0 {
+		policies = []models.SchedulingPolicy{base.Policy}
+	}
+	if len(allocators) == 0 {
+		allocators = []models.AllocationAlgorithm{base.Allocator}
+	}
+	policies = uniquePolicies(policies)
+	allocators = uniqueAllocators(allocators)
+	if maxTicks <= 0 || maxTicks > MaxCompareTicks {
+		return nil, fmt.Errorf("最大时钟周期数必须在1到%d之间", MaxCompareTicks)
+	}
+	if n := len(policies) * len(allocators); n > MaxComparisons {
+		return nil, fmt.Errorf("一次最多对比 %d 种组合，请求了 %d 种", MaxComparisons, n)
+	}
+
+	configs := make([]models.SystemConfig, 0, len(policies)*len(allocators))
+	for _, policy := range policies {
+		for _, allocator := range allocators {
+			cfg := base
+			cfg.Policy = policy
+			cfg.Allocator = allocator
+			if err := ValidateConfig(cfg); err != nil {
+				return nil, err
+			}
+			configs = append(configs, cfg)
+		}
+	}
+
+	rows := make([]models.ComparisonRow, 0, len(configs))
+	for _, cfg := range configs {
+		row := models.ComparisonRow{Policy: cfg.Policy, Allocator: cfg.Allocator}
+		result, err := RunWorkload(workload, cfg, maxTicks)
+		if err != nil {
+			row.Error = err.Error()
+		} else {
+			row.Makespan = result.Stats.Clock
+			row.AvgWaiting = result.Stats.AvgWaiting
+			row.AvgTurnaround = result.Stats.AvgTurnaround
+			row.AvgResponse = result.Stats.AvgResponse
+			row.Throughput = result.Stats.Throughput
+			row.CPUUtilization = result.Stats.CPUUtilization
+			row.ContextSwitches = result.Stats.ContextSwitches
+			row.EffectiveUtilization = result.Stats.EffectiveUtilization
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// uniquePolicies 按首次出现的顺序去掉重复的调度策略
+func uniquePolicies(policies []models.SchedulingPolicy) []models.SchedulingPolicy {
+	seen := make(map[models.SchedulingPolicy]bool, len(policies))
+	unique := make([]models.SchedulingPolicy, 0, len(policies))
+	for _, policy := range policies {
+		if !seen[policy] {
+			seen[policy] = true
+			unique = append(unique, policy)
+		}
+	}
+	return unique
+}
+
+// uniqueAllocators 按首次出现的顺序去掉重复的内存分配算法
+func uniqueAllocators(allocators []models.AllocationAlgorithm) []models.AllocationAlgorithm {
+	seen := make(map[models.AllocationAlgorithm]bool, len(allocators))
+	unique := make([]models.AllocationAlgorithm, 0, len(allocators))
+	for _, allocator := range allocators {
+		if !seen[allocator] {
+			seen[allocator] = true
+			unique = append(unique, allocator)
+		}
+	}
+	return unique
+}
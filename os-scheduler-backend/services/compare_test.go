@@ -0,0 +1,81 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"testing"
+)
+
+func TestComparePoliciesLimits(t *testing.T) {
+	workload := &models.Workload{Processes: []models.WorkloadProcess{
+		{ID: "a", Burst: 3, Memory: 10},
+		{ID: "b", Burst: 2, Memory: 10},
+	}}
+	base := DefaultConfig()
+	allPolicies := []models.SchedulingPolicy{
+		models.PolicyPriority, models.PolicyFCFS, models.PolicySJF, models.PolicyRR, models.PolicyRM, models.PolicyEDF,
+	}
+	allAllocators := []models.AllocationAlgorithm{models.FirstFit, models.NextFit, models.BestFit, models.WorstFit}
+
+	if _, err := ComparePolicies(workload, base, allPolicies, allAllocators, 100); err == nil {
+		t.Error("ComparePolicies ran more than MaxComparisons combinations")
+	}
+	if _, err := ComparePolicies(workload, base, nil, nil, MaxCompareTicks+1); err == nil {
+		t.Error("ComparePolicies accepted maxTicks above MaxCompareTicks")
+	}
+	if _, err := ComparePolicies(workload, base, []models.SchedulingPolicy{models.PolicyFCFS, "bogus"}, nil, 100); err == nil {
+		t.Error("ComparePolicies accepted an invalid policy")
+	}
+
+	rows, err := ComparePolicies(workload, base,
+		[]models.SchedulingPolicy{models.PolicyFCFS, models.PolicyRR, models.PolicyFCFS}, allAllocators[:1], 100)
+	if err != nil {
+		t.Fatalf("ComparePolicies: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2 after removing the duplicate policy", len(rows))
+	}
+	for _, row := range rows {
+		if row.Error != "" || row.Makespan == 0 {
+			t.Errorf("row %+v did not complete", row)
+		}
+	}
+}
+
+func TestStalledDetectsBlockedSystems(t *testing.T) {
+	t.Run("suspended predecessor", func(t *testing.T) {
+		s := newTestSystem(t, nil)
+		pred := addTestProcess(t, s, &models.PCB{Name: "pred", RequiredTime: 3})
+		addTestProcess(t, s, &models.PCB{Name: "succ", RequiredTime: 3, Predecessors: []int{pred}})
+		if err := s.SuspendProcess(pred); err != nil {
+			t.Fatal(err)
+		}
+		s.Schedule()
+		if !s.stalled() {
+			t.Error("a successor waiting on a suspended predecessor is not reported as stalled")
+		}
+	})
+
+	t.Run("future arrival", func(t *testing.T) {
+		s := newTestSystem(t, nil)
+		if _, err := s.SubmitWorkload(&models.Workload{Processes: []models.WorkloadProcess{
+			{ID: "late", Arrival: 5, Burst: 2},
+		}}); err != nil {
+			t.Fatal(err)
+		}
+		s.Schedule()
+		if s.stalled() {
+			t.Error("an idle system with a pending arrival is reported as stalled")
+		}
+	})
+
+	t.Run("backup admitted after dispatch", func(t *testing.T) {
+		s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.MaxProcesses = 1 })
+		addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 1})
+		addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 1})
+		s.Schedule()
+		s.Schedule()
+		if s.stalled() {
+			t.Error("a process just admitted from the backup queue is reported as stalled")
+		}
+	})
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os-scheduler-backend/models"
+	"strconv"
+	"strings"
+)
+
+// 覆盖系统参数的环境变量
+const (
+	EnvProcessors   = "OS_SCHEDULER_PROCESSORS"
+	EnvMaxProcesses = "OS_SCHEDULER_MAX_PROCESSES"
+	EnvTotalMemory  = "OS_SCHEDULER_MEMORY"
+	EnvOSMemory     = "OS_SCHEDULER_OS_MEMORY"
+	EnvPolicy       = "OS_SCHEDULER_POLICY"
+	EnvTimeQuantum  = "OS_SCHEDULER_QUANTUM"
+	EnvAllocator    = "OS_SCHEDULER_ALLOCATOR"
+
+	EnvSpeeds             = "OS_SCHEDULER_SPEEDS"
+	EnvPerProcessorQueues = "OS_SCHEDULER_PER_CPU_QUEUES"
+	EnvMigrationCost      = "OS_SCHEDULER_MIGRATION_COST"
+	EnvBalanceInterval    = "OS_SCHEDULER_BALANCE_INTERVAL"
+	EnvSeed               = "OS_SCHEDULER_SEED"
+	EnvTargetLatency      = "OS_SCHEDULER_TARGET_LATENCY"
+	EnvMinGranularity     = "OS_SCHEDULER_MIN_GRANULARITY"
+	EnvAgingInterval      = "OS_SCHEDULER_AGING_INTERVAL"
+	EnvAgingStep          = "OS_SCHEDULER_AGING_STEP"
+	EnvAgeBackup          = "OS_SCHEDULER_AGE_BACKUP"
+	EnvLockProtocol       = "OS_SCHEDULER_LOCK_PROTOCOL"
+	EnvSwitchCost         = "OS_SCHEDULER_SWITCH_COST"
+	EnvGroupShares        = "OS_SCHEDULER_GROUP_SHARES"
+)
+
+// LoadConfig 依次用配置文件和环境变量覆盖默认系统参数，path为空时不读取配置文件
+func LoadConfig(path string) (models.SystemConfig, error) {
+	cfg := DefaultConfig()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, err
+		}
+		// 文件中未出现的字段保留默认值
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+		}
+	}
+	if err := applyConfigEnv(&cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, ValidateConfig(cfg)
+}
+
+// applyConfigEnv 用已设置的环境变量覆盖系统参数
+func applyConfigEnv(cfg *models.SystemConfig) error {
+	ints := []struct {
+		name  string
+		value *int
+	}{
+		{EnvProcessors, &cfg.ProcessorCount},
+		{EnvMaxProcesses, &cfg.MaxProcesses},
+		{EnvTotalMemory, &cfg.TotalMemory},
+		{EnvOSMemory, &cfg.OSMemory},
+		{EnvTimeQuantum, &cfg.TimeQuantum},
+		{EnvMigrationCost, &cfg.MigrationCost},
+		{EnvBalanceInterval, &cfg.BalanceInterval},
+		{EnvTargetLatency, &cfg.TargetLatency},
+		{EnvMinGranularity, &cfg.MinGranularity},
+		{EnvAgingInterval, &cfg.AgingInterval},
+		{EnvAgingStep, &cfg.AgingStep},
+		{EnvSwitchCost, &cfg.ContextSwitchCost},
+	}
+	for _, env := range ints {
+		value, ok := os.LookupEnv(env.name)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("环境变量 %s 不是整数: %q", env.name, value)
+		}
+		*env.value = n
+	}
+	if value, ok := os.LookupEnv(EnvSpeeds); ok {
+		specs, err := parseSpeeds(value)
+		if err != nil {
+			return fmt.Errorf("环境变量 %s 无效: %w", EnvSpeeds, err)
+		}
+		cfg.Processors = specs
+	}
+	bools := []struct {
+		name  string
+		value *bool
+	}{
+		{EnvPerProcessorQueues, &cfg.PerProcessorQueues},
+		{EnvAgeBackup, &cfg.AgeBackup},
+	}
+	for _, env := range bools {
+		value, ok := os.LookupEnv(env.name)
+		if !ok {
+			continue
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("环境变量 %s 不是布尔值: %q", env.name, value)
+		}
+		*env.value = b
+	}
+	if value, ok := os.LookupEnv(EnvSeed); ok {
+		seed, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return fmt.Errorf("环境变量 %s 不是非负整数: %q", EnvSeed, value)
+		}
+		cfg.Seed = seed
+	}
+	if value, ok := os.LookupEnv(EnvPolicy); ok {
+		cfg.Policy = models.SchedulingPolicy(strings.TrimSpace(value))
+	}
+	if value, ok := os.LookupEnv(EnvAllocator); ok {
+		cfg.Allocator = models.AllocationAlgorithm(strings.TrimSpace(value))
+	}
+	if value, ok := os.LookupEnv(EnvLockProtocol); ok {
+		cfg.LockProtocol = models.LockProtocol(strings.TrimSpace(value))
+	}
+	if value, ok := os.LookupEnv(EnvGroupShares); ok {
+		shares, err := parseGroupShares(value)
+		if err != nil {
+			return fmt.Errorf("环境变量 %s 无效: %w", EnvGroupShares, err)
+		}
+		cfg.GroupShares = shares
+	}
+	return nil
+}
+
+// parseSpeeds 解析逗号分隔的处理机速度列表，如“2,2,1,1”
+func parseSpeeds(list string) ([]models.ProcessorSpec, error) {
+	specs := make([]models.ProcessorSpec, 0)
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		speed, err := strconv.ParseFloat(item, 64)
+		if err != nil || !validSpeed(speed) {
+			return nil, fmt.Errorf("处理机速度必须在%g到%g之间: %q", MinProcessorSpeed, MaxProcessorSpeed, item)
+		}
+		specs = append(specs, models.ProcessorSpec{Speed: speed})
+	}
+	return specs, nil
+}
+
+// ConfigFlags 命令行中的系统参数，优先级高于配置文件和环境变量
+type ConfigFlags struct {
+	fs        *flag.FlagSet
+	file      string
+	cfg       models.SystemConfig
+	policy    string
+	allocator string
+	speeds    string
+	protocol  string
+	shares    string
+}
+
+// BindConfigFlags 在fs上注册 -config 以及各系统参数的命令行参数
+func BindConfigFlags(fs *flag.FlagSet) *ConfigFlags {
+	defaults := DefaultConfig()
+	f := &ConfigFlags{fs: fs, cfg: defaults}
+	fs.StringVar(&f.file, "config", "", "JSON格式的系统参数配置文件")
+	fs.IntVar(&f.cfg.ProcessorCount, "processors", defaults.ProcessorCount, "处理机数量")
+	fs.IntVar(&f.cfg.MaxProcesses, "max-processes", defaults.MaxProcesses, "道数")
+	fs.IntVar(&f.cfg.TotalMemory, "memory", defaults.TotalMemory, "内存总大小")
+	fs.IntVar(&f.cfg.OSMemory, "os-memory", defaults.OSMemory, "操作系统占用的内存大小")
+	fs.StringVar(&f.policy, "policy", string(defaults.Policy), "调度策略：priority、fcfs、sjf、rr、rm、edf、lottery、stride、cfs、fairshare")
+	fs.IntVar(&f.cfg.TimeQuantum, "quantum", defaults.TimeQuantum, "时间片轮转的时间片长度")
+	fs.StringVar(&f.allocator, "allocator", string(defaults.Allocator), "内存分配算法：first_fit、next_fit、best_fit、worst_fit")
+	fs.StringVar(&f.speeds, "speeds", "", "逗号分隔的各处理机速度，如 2,2,1,1，缺少的处理机速度为1")
+	fs.BoolVar(&f.cfg.PerProcessorQueues, "per-cpu-queues", defaults.PerProcessorQueues, "每个处理机使用独立的就绪队列")
+	fs.IntVar(&f.cfg.MigrationCost, "migration-cost", defaults.MigrationCost, "进程换到另一个处理机时额外占用的时钟周期数")
+	fs.IntVar(&f.cfg.BalanceInterval, "balance-interval", defaults.BalanceInterval, "独立就绪队列之间负载均衡的间隔，0表示只在处理机空闲时拉取")
+	fs.Uint64Var(&f.cfg.Seed, "seed", defaults.Seed, "彩票调度使用的随机数种子")
+	fs.IntVar(&f.cfg.TargetLatency, "target-latency", defaults.TargetLatency, "完全公平调度的目标延迟")
+	fs.IntVar(&f.cfg.MinGranularity, "min-granularity", defaults.MinGranularity, "完全公平调度每次占用处理机的最短时间")
+	fs.IntVar(&f.cfg.AgingInterval, "aging-interval", defaults.AgingInterval, "动态优先数策略下进程每连续等待多少个时钟周期提高一次优先数，0表示不老化")
+	fs.IntVar(&f.cfg.AgingStep, "aging-step", defaults.AgingStep, "每次老化增加的优先数")
+	fs.BoolVar(&f.cfg.AgeBackup, "age-backup", defaults.AgeBackup, "后备队列中的进程也参与老化，并按优先数调入")
+	fs.IntVar(&f.cfg.ContextSwitchCost, "switch-cost", defaults.ContextSwitchCost, "处理机换一个进程运行时上下文切换占用的时钟周期数")
+	fs.StringVar(&f.protocol, "lock-protocol", string(defaults.LockProtocol), "锁协议：none、inheritance、ceiling")
+	fs.StringVar(&f.shares, "group-shares", "", "逗号分隔的公平份额调度用户组份额，如 lab=3,course=1，未列出的组份额为1")
+	return f
+}
+
+// Load 在解析命令行之后调用，返回按 默认值、配置文件、环境变量、命令行参数 依次覆盖后的系统参数
+func (f *ConfigFlags) Load() (models.SystemConfig, error) {
+	cfg, err := LoadConfig(f.file)
+	if err != nil {
+		return cfg, err
+	}
+	var sharesErr error
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "processors":
+			cfg.ProcessorCount = f.cfg.ProcessorCount
+		case "max-processes":
+			cfg.MaxProcesses = f.cfg.MaxProcesses
+		case "memory":
+			cfg.TotalMemory = f.cfg.TotalMemory
+		case "os-memory":
+			cfg.OSMemory = f.cfg.OSMemory
+		case "policy":
+			cfg.Policy = models.SchedulingPolicy(f.policy)
+		case "quantum":
+			cfg.TimeQuantum = f.cfg.TimeQuantum
+		case "allocator":
+			cfg.Allocator = models.AllocationAlgorithm(f.allocator)
+		case "speeds":
+			cfg.Processors, err = parseSpeeds(f.speeds)
+		case "per-cpu-queues":
+			cfg.PerProcessorQueues = f.cfg.PerProcessorQueues
+		case "migration-cost":
+			cfg.MigrationCost = f.cfg.MigrationCost
+		case "balance-interval":
+			cfg.BalanceInterval = f.cfg.BalanceInterval
+		case "seed":
+			cfg.Seed = f.cfg.Seed
+		case "target-latency":
+			cfg.TargetLatency = f.cfg.TargetLatency
+		case "min-granularity":
+			cfg.MinGranularity = f.cfg.MinGranularity
+		case "aging-interval":
+			cfg.AgingInterval = f.cfg.AgingInterval
+		case "aging-step":
+			cfg.AgingStep = f.cfg.AgingStep
+		case "age-backup":
+			cfg.AgeBackup = f.cfg.AgeBackup
+		case "switch-cost":
+			cfg.ContextSwitchCost = f.cfg.ContextSwitchCost
+		case "lock-protocol":
+			cfg.LockProtocol = models.LockProtocol(f.protocol)
+		case "group-shares":
+			cfg.GroupShares, sharesErr = parseGroupShares(f.shares)
+		}
+	})
+	if err != nil {
+		return cfg, fmt.Errorf("参数 -speeds 无效: %w", err)
+	}
+	if sharesErr != nil {
+		return cfg, fmt.Errorf("参数 -group-shares 无效: %w", sharesErr)
+	}
+	return cfg, ValidateConfig(cfg)
+}
+
+// ConfigConflictError 修改的系统参数需要重置系统才能生效
+type ConfigConflictError struct {
+	Fields []string // 需要重置才能修改的参数名
+}
+
+func (e *ConfigConflictError) Error() string {
+	return fmt.Sprintf("修改参数 %s 需要重置系统", strings.Join(e.Fields, ", "))
+}
+
+// resetFields 返回从old改为cfg时需要重置系统才能修改的参数
+func resetFields(old, cfg models.SystemConfig) []string {
+	fields := make([]string, 0)
+	if cfg.TotalMemory != old.TotalMemory {
+		fields = append(fields, "totalMemory")
+	}
+	if cfg.OSMemory != old.OSMemory {
+		fields = append(fields, "osMemory")
+	}
+	return fields
+}
+
+// Configure 修改系统参数。内存大小以外的参数都立即生效，
+// 减少处理机时被移除的处理机上的进程回到就绪队列；内存大小会改变已有进程的内存分配，
+// 需要重置系统才能修改，reset为false时返回 *ConfigConflictError。reset为true时总是先清空所有进程
+func (s *Scheduler) Configure(cfg models.SystemConfig, reset bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := ValidateConfig(cfg); err != nil {
+		return err
+	}
+	if fields := resetFields(s.config(), cfg); len(fields) > 0 && !reset {
+		return &ConfigConflictError{Fields: fields}
+	}
+
+	if !reset {
+		if err := s.checkGangs(nil, cfg.ProcessorCount, cfg.MaxProcesses); err != nil {
+			return err
+		}
+	}
+	if reset {
+		s.reset()
+		s.ProcessorCount = cfg.ProcessorCount
+		s.memoryManager.Memory = newMemory(cfg.TotalMemory, cfg.OSMemory)
+	} else if err := s.setProcessorCount(cfg.ProcessorCount); err != nil {
+		return err
+	}
+	s.Processors = normalizeProcessors(cfg.Processors, cfg.ProcessorCount)
+	s.MaxProcesses = cfg.MaxProcesses
+	s.Policy = cfg.Policy
+	s.TimeQuantum = cfg.TimeQuantum
+	s.PerProcessorQueues = cfg.PerProcessorQueues
+	s.MigrationCost = cfg.MigrationCost
+	s.BalanceInterval = cfg.BalanceInterval
+	s.TargetLatency = cfg.TargetLatency
+	s.MinGranularity = cfg.MinGranularity
+	s.AgingInterval = cfg.AgingInterval
+	s.AgingStep = cfg.AgingStep
+	s.AgeBackup = cfg.AgeBackup
+	s.LockProtocol = cfg.LockProtocol
+	s.ContextSwitchCost = cfg.ContextSwitchCost
+	s.GroupShares = cloneShares(cfg.GroupShares)
+	// 种子改变或重置系统时随机数序列从头开始
+	if reset || cfg.Seed != s.Seed {
+		s.Seed = cfg.Seed
+		s.randomState = cfg.Seed
+	}
+	s.memoryManager.Memory.Algorithm = cfg.Allocator
+	s.sortReadyQueue()
+	// 道数变大时立即从后备队列调入进程
+	s.admitBackup()
+
+	s.eventLog.Record(OpConfigure, configureArgs{Config: cfg, Reset: reset})
+	return nil
+}
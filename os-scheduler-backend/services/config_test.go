@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"os-scheduler-backend/models"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeConfigFile 在临时目录中写入配置文件并返回路径
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestConfigSourcesOverrideInOrder(t *testing.T) {
+	path := writeConfigFile(t, `{"processorCount": 3, "maxProcesses": 5, "policy": "rr", "timeQuantum": 4}`)
+	t.Setenv(EnvMaxProcesses, "6")
+	t.Setenv(EnvPolicy, "fcfs")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := BindConfigFlags(fs)
+	if err := fs.Parse([]string{"-config", path, "-policy", "sjf"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	cfg, err := flags.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	// 默认值 < 配置文件 < 环境变量 < 命令行参数
+	want := DefaultConfig()
+	want.ProcessorCount = 3
+	want.TimeQuantum = 4
+	want.MaxProcesses = 6
+	want.Policy = models.PolicySJF
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("config = %+v\nwant %+v", cfg, want)
+	}
+}
+
+func TestLoadConfigRejectsInvalidSources(t *testing.T) {
+	if _, err := LoadConfig(writeConfigFile(t, `{"processorCount": `)); err == nil {
+		t.Error("LoadConfig accepted a malformed file")
+	}
+	if _, err := LoadConfig(writeConfigFile(t, `{"processorCount": 0}`)); err == nil {
+		t.Error("LoadConfig accepted zero processors")
+	}
+
+	t.Setenv(EnvTimeQuantum, "two")
+	_, err := LoadConfig("")
+	if err == nil || !strings.Contains(err.Error(), EnvTimeQuantum) {
+		t.Errorf("LoadConfig error = %v, want an error naming %s", err, EnvTimeQuantum)
+	}
+}
+
+func TestConfigureMemoryNeedsReset(t *testing.T) {
+	s := newTestSystem(t, nil)
+	addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 3, MemorySize: 64})
+	cfg := s.Config()
+	cfg.TotalMemory = 8192
+
+	var conflict *ConfigConflictError
+	if err := s.Configure(cfg, false); !errors.As(err, &conflict) || !reflect.DeepEqual(conflict.Fields, []string{"totalMemory"}) {
+		t.Fatalf("Configure error = %v, want a conflict on totalMemory", err)
+	}
+	if s.Config().TotalMemory != 4096 || len(s.allProcesses()) != 1 {
+		t.Fatal("rejected Configure changed the system")
+	}
+
+	if err := s.Configure(cfg, true); err != nil {
+		t.Fatalf("Configure with reset: %v", err)
+	}
+	if s.Config().TotalMemory != 8192 || len(s.allProcesses()) != 0 {
+		t.Errorf("reset Configure: memory=%d processes=%d", s.Config().TotalMemory, len(s.allProcesses()))
+	}
+}
+
+func TestConfigureAppliesImmediately(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.MaxProcesses = 1 })
+	addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 3})
+	addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 3})
+	if len(s.Queue.Backup) != 1 {
+		t.Fatalf("backup queue has %d processes, want 1", len(s.Queue.Backup))
+	}
+
+	cfg := s.Config()
+	cfg.MaxProcesses = 2
+	cfg.Policy = models.PolicyRR
+	cfg.TimeQuantum = 3
+	if err := s.Configure(cfg, false); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	// 道数变大时立即从后备队列调入进程
+	if len(s.Queue.Backup) != 0 || len(s.Queue.Ready) != 2 {
+		t.Errorf("after Configure: ready=%d backup=%d, want 2 and 0", len(s.Queue.Ready), len(s.Queue.Backup))
+	}
+	if got := s.Config(); got.Policy != models.PolicyRR || got.TimeQuantum != 3 {
+		t.Errorf("config = %+v", got)
+	}
+
+	cfg.TimeQuantum = 0
+	if err := s.Configure(cfg, false); err == nil {
+		t.Error("Configure accepted a zero time quantum")
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import "os-scheduler-backend/models"
+
+// loadedProcesses 返回各处理机上最近运行过的进程，即上下文仍装载在处理机上的进程，
+// -1表示从未运行过进程。由时间线导出并缓存，处理机数量变化、重置或恢复后重新导出
+func (s *Scheduler) loadedProcesses() []int {
+	if len(s.loaded) != s.ProcessorCount {
+		s.loaded = make([]int, s.ProcessorCount)
+		for cpu := range s.loaded {
+			s.loaded[cpu] = -1
+		}
+		for _, slot := range s.Timeline {
+			s.load(slot)
+		}
+	}
+	return s.loaded
+}
+
+// load 用一个时钟周期的时间线更新各处理机上装载的进程，空闲的处理机保持原来的进程
+func (s *Scheduler) load(slot models.TimelineSlot) {
+	for cpu, pid := range slot.Processors {
+		if pid >= 0 && cpu < len(s.loaded) {
+			s.loaded[cpu] = pid
+		}
+	}
+}
+
+// chargeSwitch 进程被分配到装载着另一个进程的处理机上时发生上下文切换，
+// 先占用处理机 ContextSwitchCost 个时钟周期而不推进。处理机上次运行的就是该进程或从未运行过进程时不切换
+func (s *Scheduler) chargeSwitch(p *models.PCB, cpu int) {
+	if loaded := s.loadedProcesses()[cpu]; loaded >= 0 && loaded != p.PID {
+		p.SwitchTicks = s.ContextSwitchCost
+	}
+}
+
+// switchStats 按时间线统计上下文切换：同一处理机上先后运行的两个进程不同记为一次切换，
+// 计入该处理机和切换到的进程。返回各处理机的切换次数和开销时钟周期数，以及各进程被切换到的次数
+func switchStats(timeline []models.TimelineSlot, processors int) (switches, overhead []int, byPID map[int]int) {
+	switches = make([]int, processors)
+	overhead = make([]int, processors)
+	byPID = make(map[int]int)
+	last := make(map[int]int)
+	for _, slot := range timeline {
+		for cpu, pid := range slot.Processors {
+			if pid < 0 {
+				continue
+			}
+			if prev, ok := last[cpu]; ok && prev != pid {
+				byPID[pid]++
+				if cpu < processors {
+					switches[cpu]++
+				}
+			}
+			last[cpu] = pid
+			if cpu < len(slot.Switching) && slot.Switching[cpu] && cpu < processors {
+				overhead[cpu]++
+			}
+		}
+	}
+	return switches, overhead, byPID
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"testing"
+)
+
+// roundRobinWorkload 三个进程在单处理机上按时间片轮转运行
+func roundRobinWorkload() *models.Workload {
+	return &models.Workload{Processes: []models.WorkloadProcess{
+		{ID: "a", Burst: 8},
+		{ID: "b", Burst: 6},
+		{ID: "c", Burst: 4},
+	}}
+}
+
+// runRoundRobin 在单处理机、时间片为2的时间片轮转下运行负载
+func runRoundRobin(t *testing.T, workload *models.Workload, cost int) *models.SimulationResult {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.ProcessorCount = 1
+	cfg.Policy = models.PolicyRR
+	cfg.TimeQuantum = 2
+	cfg.ContextSwitchCost = cost
+	result, err := RunWorkload(workload, cfg, 200)
+	if err != nil {
+		t.Fatalf("RunWorkload: %v", err)
+	}
+	return result
+}
+
+func TestContextSwitchCost(t *testing.T) {
+	// 18个时间单位的工作在时间片为2时切换8次，每次切换多占用cost个时钟周期
+	for _, cost := range []int{0, 1, 2} {
+		result := runRoundRobin(t, roundRobinWorkload(), cost)
+		stats := result.Stats
+		if want := 18 + 8*cost; stats.Clock != want {
+			t.Errorf("cost %d: makespan = %d, want %d", cost, stats.Clock, want)
+		}
+		if stats.ContextSwitches != 8 || stats.OverheadTicks != 8*cost {
+			t.Errorf("cost %d: switches = %d, overhead = %d, want 8 and %d", cost, stats.ContextSwitches, stats.OverheadTicks, 8*cost)
+		}
+		if want := 18 / float64(stats.Clock); stats.EffectiveUtilization != want {
+			t.Errorf("cost %d: effective utilization = %v, want %v", cost, stats.EffectiveUtilization, want)
+		}
+		cpu := stats.PerProcessor[0]
+		if cpu.Switches != 8 || cpu.OverheadTicks != 8*cost {
+			t.Errorf("cost %d: per-processor switches = %d, overhead = %d", cost, cpu.Switches, cpu.OverheadTicks)
+		}
+
+		switching := 0
+		for _, slot := range result.Timeline {
+			if len(slot.Switching) > 0 && slot.Switching[0] {
+				switching++
+			}
+		}
+		if switching != 8*cost {
+			t.Errorf("cost %d: %d timeline slots marked as switching, want %d", cost, switching, 8*cost)
+		}
+	}
+}
+
+func TestNoSwitchWhenProcessKeepsProcessor(t *testing.T) {
+	// 只有一个进程时每个时间片结束后它继续运行，不发生切换
+	result := runRoundRobin(t, &models.Workload{Processes: []models.WorkloadProcess{{ID: "a", Burst: 7}}}, 3)
+	if result.Stats.Clock != 7 || result.Stats.ContextSwitches != 0 {
+		t.Errorf("makespan = %d, switches = %d, want 7 and 0", result.Stats.Clock, result.Stats.ContextSwitches)
+	}
+}
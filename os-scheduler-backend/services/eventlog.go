@@ -0,0 +1,461 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os-scheduler-backend/models"
+	"sync"
+)
+
+// 事件日志中的操作名称
+const (
+	OpInit           = "init" // 开始记录时的完整状态
+	OpAddProcess     = "add_process"
+	OpAddBatch       = "add_batch"
+	OpSubmitWorkload = "submit_workload"
+	OpSetPolicy      = "set_policy"
+	OpSetAllocator   = "set_allocator"
+	OpConfigure      = "configure"
+	OpSetProcessors  = "set_processors"
+	OpSetAffinity    = "set_affinity"
+	OpDeclareGang    = "declare_gang"
+	OpSetTickets     = "set_tickets"
+	OpAddTask        = "add_task"
+	OpRemoveTask     = "remove_task"
+	OpReleaseJob     = "release_job"
+	OpSchedule       = "schedule"
+	OpSuspend        = "suspend"
+	OpResume         = "resume"
+	OpReset          = "reset"
+	OpRewind         = "rewind"
+	OpRestore        = "restore"
+	OpSetHistorySize = "set_history_size"
+	OpAllocate       = "allocate"
+	OpFree           = "free"
+)
+
+type initArgs struct {
+	Snapshot    *models.Snapshot `json:"snapshot"`
+	HistorySize int              `json:"historySize"`
+}
+
+type addProcessArgs struct {
+	Process *models.PCB `json:"process"` // 提交时的进程信息
+	PID     int         `json:"pid"`     // 分配到的PID
+}
+
+type addBatchArgs struct {
+	Processes []models.BatchProcess `json:"processes"`
+	Mapping   map[string]int        `json:"mapping"`
+}
+
+type submitWorkloadArgs struct {
+	Workload *models.Workload `json:"workload"`
+	Mapping  map[string]int   `json:"mapping"`
+}
+
+type policyArgs struct {
+	Policy      models.SchedulingPolicy `json:"policy"`
+	TimeQuantum int                     `json:"timeQuantum"`
+}
+
+type allocatorArgs struct {
+	Allocator models.AllocationAlgorithm `json:"allocator"`
+}
+
+type configureArgs struct {
+	Config models.SystemConfig `json:"config"`
+	Reset  bool                `json:"reset"`
+}
+
+type processorsArgs struct {
+	Count int `json:"count"`
+}
+
+type affinityArgs struct {
+	PID  int    `json:"pid"`
+	Mask uint64 `json:"mask"`
+}
+
+type ticketsArgs struct {
+	PID     int `json:"pid"`
+	Tickets int `json:"tickets"`
+}
+
+type gangArgs struct {
+	Name string `json:"name"`
+	PIDs []int  `json:"pids"`
+}
+
+type addTaskArgs struct {
+	Task *models.RealTimeTask `json:"task"` // 提交时的任务信息
+	ID   int                  `json:"id"`   // 分配到的任务ID
+}
+
+type taskArgs struct {
+	ID int `json:"id"`
+}
+
+type releaseJobArgs struct {
+	TaskID int `json:"taskId"`
+	PID    int `json:"pid"` // 作业分配到的PID
+}
+
+type pidArgs struct {
+	PID int `json:"pid"`
+}
+
+type scheduleArgs struct {
+	Clock int `json:"clock"` // 调度后的时钟
+}
+
+type rewindArgs struct {
+	Steps int `json:"steps"`
+}
+
+type restoreArgs struct {
+	Snapshot *models.Snapshot `json:"snapshot"`
+}
+
+type historySizeArgs struct {
+	Size int `json:"size"`
+}
+
+type allocateArgs struct {
+	Size  int `json:"size"`
+	Start int `json:"start"` // 分配到的起始地址
+}
+
+type freeArgs struct {
+	Start int `json:"start"`
+}
+
+// EventLog 只追加的JSONL事件日志
+type EventLog struct {
+	mutex  sync.Mutex
+	writer io.Writer
+	closer io.Closer
+	seq    int
+}
+
+// NewEventLog 创建写入w的事件日志
+func NewEventLog(w io.Writer) *EventLog {
+	return &EventLog{writer: w}
+}
+
+// OpenEventLog 以追加方式打开事件日志文件，序号接着文件中已有的记录编号
+func OpenEventLog(path string) (*EventLog, error) {
+	seq := 0
+	if f, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+		for scanner.Scan() {
+			if len(scanner.Bytes()) > 0 {
+				seq++
+			}
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &EventLog{writer: file, closer: file, seq: seq}, nil
+}
+
+// Record 追加一条事件，日志为空时不做任何事
+func (l *EventLog) Record(op string, args interface{}) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	event := models.Event{Seq: l.seq + 1, Op: op}
+	if args != nil {
+		data, err := json.Marshal(args)
+		if err != nil {
+			log.Printf("记录事件 %s 失败: %v", op, err)
+			return
+		}
+		event.Args = data
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("记录事件 %s 失败: %v", op, err)
+		return
+	}
+	if _, err := l.writer.Write(append(line, '\n')); err != nil {
+		log.Printf("记录事件 %s 失败: %v", op, err)
+		return
+	}
+	l.seq++
+}
+
+// Close 关闭日志文件
+func (l *EventLog) Close() error {
+	if l == nil || l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// SetEventLog 开始将改变状态的调用记录到事件日志，并先记录当前的完整状态，
+// 使日志可以独立重放。传入nil时停止记录
+func (s *Scheduler) SetEventLog(eventLog *EventLog) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.eventLog = eventLog
+	s.memoryManager.eventLog = eventLog
+	s.eventLog.Record(OpInit, initArgs{Snapshot: s.snapshot(), HistorySize: len(s.history.items)})
+}
+
+// Replay 从空状态开始依次重放事件日志中的调用。每个调用的结果（PID、内存地址、时钟）
+// 都会与日志中记录的结果比对，不一致时返回错误。
+// 重放在一个不记录日志的新调度器上进行，全部成功后才替换当前状态，失败时当前状态不变。
+// 重放的调用不会写入当前的事件日志，日志中只追加一条重放结果的恢复记录
+func (s *Scheduler) Replay(r io.Reader) error {
+	s.mutex.Lock()
+	fresh := NewScheduler(s.ProcessorCount, s.MaxProcesses,
+		NewMemoryManager(s.memoryManager.Memory.TotalSize, s.memoryManager.Memory.OSSize))
+	err := fresh.restore(s.snapshot())
+	fresh.history = newSnapshotRing(len(s.history.items))
+	s.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	fresh.reset()
+
+	decoder := json.NewDecoder(r)
+	for {
+		var event models.Event
+		if err := decoder.Decode(&event); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("解析事件失败: %w", err)
+		}
+		if err := fresh.applyEvent(event); err != nil {
+			return fmt.Errorf("重放第 %d 条事件 %s 失败: %w", event.Seq, event.Op, err)
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snap := fresh.snapshot()
+	if err := s.restore(snap); err != nil {
+		return err
+	}
+	s.history = fresh.history
+	s.eventLog.Record(OpRestore, restoreArgs{Snapshot: snap})
+	return nil
+}
+
+func (s *Scheduler) applyEvent(event models.Event) error {
+	decode := func(v interface{}) error {
+		if len(event.Args) == 0 {
+			return nil
+		}
+		return json.Unmarshal(event.Args, v)
+	}
+
+	switch event.Op {
+	case OpInit:
+		var args initArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		s.SetHistorySize(args.HistorySize)
+		return s.Restore(args.Snapshot)
+	case OpAddProcess:
+		var args addProcessArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		if args.Process == nil {
+			return fmt.Errorf("缺少进程信息")
+		}
+		process := args.Process.Clone()
+		if err := s.AddProcess(process); err != nil {
+			return err
+		}
+		if process.PID != args.PID {
+			return fmt.Errorf("分配的PID为 %d，日志中为 %d", process.PID, args.PID)
+		}
+	case OpAddBatch:
+		var args addBatchArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		mapping, _, err := s.AddProcessBatch(args.Processes)
+		if err != nil {
+			return err
+		}
+		for id, pid := range args.Mapping {
+			if mapping[id] != pid {
+				return fmt.Errorf("进程 %s 分配的PID为 %d，日志中为 %d", id, mapping[id], pid)
+			}
+		}
+	case OpSubmitWorkload:
+		var args submitWorkloadArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		if args.Workload == nil {
+			return fmt.Errorf("缺少负载信息")
+		}
+		mapping, err := s.SubmitWorkload(args.Workload)
+		if err != nil {
+			return err
+		}
+		for id, pid := range args.Mapping {
+			if mapping[id] != pid {
+				return fmt.Errorf("进程 %s 分配的PID为 %d，日志中为 %d", id, mapping[id], pid)
+			}
+		}
+	case OpSetPolicy:
+		var args policyArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		return s.SetPolicy(args.Policy, args.TimeQuantum)
+	case OpSetAllocator:
+		var args allocatorArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		return s.memoryManager.SetAlgorithm(args.Allocator)
+	case OpConfigure:
+		var args configureArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		return s.Configure(args.Config, args.Reset)
+	case OpSetProcessors:
+		var args processorsArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		return s.SetProcessorCount(args.Count)
+	case OpSetAffinity:
+		var args affinityArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		return s.SetAffinity(args.PID, args.Mask)
+	case OpSetTickets:
+		var args ticketsArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		return s.SetTickets(args.PID, args.Tickets)
+	case OpDeclareGang:
+		var args gangArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		return s.DeclareGang(args.Name, args.PIDs)
+	case OpAddTask:
+		var args addTaskArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		if args.Task == nil {
+			return fmt.Errorf("缺少任务信息")
+		}
+		task := *args.Task
+		if err := s.AddTask(&task); err != nil {
+			return err
+		}
+		if task.ID != args.ID {
+			return fmt.Errorf("分配的任务ID为 %d，日志中为 %d", task.ID, args.ID)
+		}
+	case OpRemoveTask:
+		var args taskArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		return s.RemoveTask(args.ID)
+	case OpReleaseJob:
+		var args releaseJobArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		pid, err := s.ReleaseJob(args.TaskID)
+		if err != nil {
+			return err
+		}
+		if pid != args.PID {
+			return fmt.Errorf("作业分配的PID为 %d，日志中为 %d", pid, args.PID)
+		}
+	case OpSchedule:
+		var args scheduleArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		s.Schedule()
+		if s.Clock != args.Clock {
+			return fmt.Errorf("调度后时钟为 %d，日志中为 %d", s.Clock, args.Clock)
+		}
+	case OpSuspend, OpResume:
+		var args pidArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		if event.Op == OpSuspend {
+			return s.SuspendProcess(args.PID)
+		}
+		return s.ResumeProcess(args.PID)
+	case OpReset:
+		s.Reset()
+	case OpRewind:
+		var args rewindArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		_, err := s.Rewind(args.Steps)
+		return err
+	case OpRestore:
+		var args restoreArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		return s.Restore(args.Snapshot)
+	case OpSetHistorySize:
+		var args historySizeArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		s.SetHistorySize(args.Size)
+	case OpAllocate:
+		var args allocateArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		start, err := s.memoryManager.Allocate(args.Size)
+		if err != nil {
+			return err
+		}
+		if start != args.Start {
+			return fmt.Errorf("分配的地址为 %d，日志中为 %d", start, args.Start)
+		}
+	case OpFree:
+		var args freeArgs
+		if err := decode(&args); err != nil {
+			return err
+		}
+		s.memoryManager.Free(args.Start)
+	default:
+		return fmt.Errorf("未知的操作 %s", event.Op)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"os-scheduler-backend/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// recordedSystem 创建一个记录事件日志的调度器，添加两个进程并调度几次
+func recordedSystem(t *testing.T) (*Scheduler, *bytes.Buffer) {
+	t.Helper()
+	s := newTestSystem(t, nil)
+	var buf bytes.Buffer
+	s.SetEventLog(NewEventLog(&buf))
+	addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 4, MemorySize: 10})
+	addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 3, MemorySize: 20})
+	for i := 0; i < 4; i++ {
+		s.Schedule()
+	}
+	return s, &buf
+}
+
+func TestReplayDoesNotDuplicateLog(t *testing.T) {
+	s, buf := recordedSystem(t)
+	want := s.Snapshot()
+	logged := buf.String()
+
+	if err := s.Replay(strings.NewReader(logged)); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if got := s.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("state after replay differs\ngot:  %+v\nwant: %+v", got, want)
+	}
+
+	appended := strings.TrimPrefix(buf.String(), logged)
+	lines := strings.Split(strings.TrimSpace(appended), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("replay appended %d log lines, want 1", len(lines))
+	}
+	var event models.Event
+	if err := json.Unmarshal([]byte(lines[0]), &event); err != nil || event.Op != OpRestore {
+		t.Errorf("appended event = %q, want a restore", lines[0])
+	}
+}
+
+func TestFailedReplayLeavesStateUntouched(t *testing.T) {
+	s, buf := recordedSystem(t)
+	// 日志最后一条调度记录的时钟对不上，重放在最后一步失败
+	bad := buf.String() + `{"seq":99,"op":"schedule","args":{"clock":1000}}` + "\n"
+
+	addTestProcess(t, s, &models.PCB{Name: "c", RequiredTime: 2})
+	want := s.Snapshot()
+	history := s.HistoryLength()
+	logged := buf.Len()
+
+	if err := s.Replay(strings.NewReader(bad)); err == nil {
+		t.Fatal("Replay accepted a log with a mismatched clock")
+	}
+	if got := s.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("failed replay changed the state")
+	}
+	if s.HistoryLength() != history {
+		t.Errorf("history length = %d, want %d", s.HistoryLength(), history)
+	}
+	if buf.Len() != logged {
+		t.Errorf("failed replay wrote to the event log: %s", buf.String()[logged:])
+	}
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os-scheduler-backend/models"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultOwner 未指定用户或用户组的进程所属的用户和用户组
+const DefaultOwner = "default"
+
+// DefaultGroupShares 未配置份额的用户组的份额
+const DefaultGroupShares = 1
+
+// MaxGroupShares 用户组份额的上限，保证公平份额调度的步长至少为1
+const MaxGroupShares = strideConstant
+
+// userOf 返回进程所属的用户
+func userOf(p *models.PCB) string {
+	if p.User == "" {
+		return DefaultOwner
+	}
+	return p.User
+}
+
+// groupOf 返回进程所属的用户组
+func groupOf(p *models.PCB) string {
+	if p.Group == "" {
+		return DefaultOwner
+	}
+	return p.Group
+}
+
+// validGroupShares 判断用户组份额是否有效：组名不能为空，份额必须在1到 MaxGroupShares 之间
+func validGroupShares(shares map[string]int) error {
+	for group, n := range shares {
+		if group == "" {
+			return errors.New("用户组份额中的组名不能为空")
+		}
+		if n <= 0 || n > MaxGroupShares {
+			return fmt.Errorf("用户组 %s 的份额必须在1到%d之间", group, MaxGroupShares)
+		}
+	}
+	return nil
+}
+
+// parseGroupShares 解析逗号分隔的用户组份额列表，每项为“组名=份额”，如“lab=3,course=1”
+func parseGroupShares(list string) (map[string]int, error) {
+	shares := make(map[string]int)
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("用户组份额应写成 组名=份额: %q", item)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("用户组份额必须是整数: %q", item)
+		}
+		shares[strings.TrimSpace(parts[0])] = n
+	}
+	return shares, validGroupShares(shares)
+}
+
+// cloneShares 复制用户组份额，没有配置时返回nil
+func cloneShares(shares map[string]int) map[string]int {
+	if len(shares) == 0 {
+		return nil
+	}
+	clone := make(map[string]int, len(shares))
+	for group, n := range shares {
+		clone[group] = n
+	}
+	return clone
+}
+
+// groupShares 返回用户组的份额
+func (s *Scheduler) groupShares(group string) int {
+	if n, ok := s.GroupShares[group]; ok {
+		return n
+	}
+	return DefaultGroupShares
+}
+
+// fairStrides 返回就绪和运行中的各进程在公平份额调度中的步长。处理机时间先按份额分给有可运行进程的用户组，
+// 组内再按彩票数分给组内的可运行进程，进程的有效权重为 组份额×进程彩票数/组内可运行进程的彩票总数，
+// 步长为常数除以有效权重。组内进程越多，每个进程分到的越少，但整个组得到的处理机时间不变
+func (s *Scheduler) fairStrides() map[int]int {
+	runnable := append(append([]*models.PCB(nil), s.Queue.Running...), s.Queue.Ready...)
+	groupTickets := make(map[string]int)
+	for _, p := range runnable {
+		groupTickets[groupOf(p)] += tickets(p)
+	}
+	strides := make(map[int]int, len(runnable))
+	for _, p := range runnable {
+		group := groupOf(p)
+		strides[p.PID] = strideConstant * groupTickets[group] / (s.groupShares(group) * tickets(p))
+	}
+	return strides
+}
+
+// liftFairPass 与 liftPass 相同，以上一个时钟周期运行过的进程运行前的最小行程值为基准，
+// 落后基准超过一个步长的就绪进程从基准开始，不能凭不在就绪队列期间落后的行程值长期独占处理机
+func (s *Scheduler) liftFairPass(strides map[int]int) {
+	if len(s.Timeline) == 0 {
+		return
+	}
+	floor := -1
+	for _, pid := range s.Timeline[len(s.Timeline)-1].Processors {
+		step, ok := strides[pid]
+		if pid < 0 || !ok {
+			continue
+		}
+		if p := s.findProcess(pid); p != nil && (floor < 0 || p.FairPass-step < floor) {
+			floor = p.FairPass - step
+		}
+	}
+	if floor < 0 {
+		return
+	}
+	for _, p := range s.Queue.Ready {
+		if p.FairPass < floor-strides[p.PID] {
+			p.FairPass = floor
+		}
+	}
+}
+
+// ownerStats 按用户组和用户汇总进程占用的处理机时间，都按名称排序。
+// 组的期望比例为其份额占有进程的各组份额之和的比例
+func (s *Scheduler) ownerStats(processes []*models.PCB) ([]models.GroupStats, []models.UserStats) {
+	groups := make(map[string]*models.GroupStats)
+	users := make(map[string]*models.UserStats)
+	total := 0
+	for _, p := range processes {
+		g, ok := groups[groupOf(p)]
+		if !ok {
+			g = &models.GroupStats{Name: groupOf(p), Shares: s.groupShares(groupOf(p))}
+			groups[g.Name] = g
+		}
+		u, ok := users[userOf(p)]
+		if !ok {
+			u = &models.UserStats{Name: userOf(p)}
+			users[u.Name] = u
+		}
+		g.Processes++
+		u.Processes++
+		g.CPUTime += p.CPUTime
+		u.CPUTime += p.CPUTime
+		if p.State == models.Finished {
+			g.Finished++
+			u.Finished++
+		}
+		total += p.CPUTime
+	}
+
+	shares := 0
+	for _, g := range groups {
+		shares += g.Shares
+	}
+	groupStats := make([]models.GroupStats, 0, len(groups))
+	for _, g := range groups {
+		if total > 0 {
+			g.Usage = float64(g.CPUTime) / float64(total)
+		}
+		g.ExpectedShare = float64(g.Shares) / float64(shares)
+		groupStats = append(groupStats, *g)
+	}
+	sort.Slice(groupStats, func(i, j int) bool {
+		return groupStats[i].Name < groupStats[j].Name
+	})
+	userStats := make([]models.UserStats, 0, len(users))
+	for _, u := range users {
+		if total > 0 {
+			u.Usage = float64(u.CPUTime) / float64(total)
+		}
+		userStats = append(userStats, *u)
+	}
+	sort.Slice(userStats, func(i, j int) bool {
+		return userStats[i].Name < userStats[j].Name
+	})
+	return groupStats, userStats
+}
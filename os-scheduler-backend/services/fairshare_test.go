@@ -0,0 +1,89 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"reflect"
+	"testing"
+)
+
+// groupUsage 在单处理机公平份额调度下运行ticks个时钟周期，返回各用户组占用的处理机时间
+func groupUsage(t *testing.T, shares map[string]int, processes []*models.PCB, ticks int) (*Scheduler, map[string]int) {
+	t.Helper()
+	s := newTestSystem(t, func(cfg *models.SystemConfig) {
+		cfg.ProcessorCount = 1
+		cfg.Policy = models.PolicyFairShare
+		cfg.GroupShares = shares
+	})
+	for _, p := range processes {
+		addTestProcess(t, s, p)
+	}
+	for s.Clock < ticks {
+		s.Schedule()
+	}
+	usage := make(map[string]int)
+	for _, p := range s.allProcesses() {
+		usage[groupOf(p)] += p.CPUTime
+	}
+	return s, usage
+}
+
+func TestFairShareIgnoresProcessCount(t *testing.T) {
+	// lab组有3个进程，course组只有1个，份额相同时两组各占一半处理机时间
+	_, usage := groupUsage(t, nil, []*models.PCB{
+		{Name: "l1", RequiredTime: 100, Group: "lab", User: "alice"},
+		{Name: "l2", RequiredTime: 100, Group: "lab", User: "alice"},
+		{Name: "l3", RequiredTime: 100, Group: "lab", User: "bob"},
+		{Name: "c1", RequiredTime: 100, Group: "course", User: "carol"},
+	}, 60)
+	if usage["lab"] < 28 || usage["lab"] > 32 || usage["lab"]+usage["course"] != 60 {
+		t.Errorf("usage = %v, want about 30 each", usage)
+	}
+}
+
+func TestFairShareFollowsShares(t *testing.T) {
+	s, usage := groupUsage(t, map[string]int{"lab": 3, "course": 1}, []*models.PCB{
+		{Name: "l1", RequiredTime: 100, Group: "lab"},
+		{Name: "c1", RequiredTime: 100, Group: "course"},
+		{Name: "c2", RequiredTime: 100, Group: "course"},
+	}, 80)
+	if usage["lab"] < 58 || usage["lab"] > 62 {
+		t.Errorf("usage = %v, want lab about 60 of 80", usage)
+	}
+
+	stats := s.Stats()
+	if len(stats.Groups) != 2 || stats.Groups[0].Name != "course" || stats.Groups[1].Name != "lab" {
+		t.Fatalf("groups = %+v, want course and lab sorted by name", stats.Groups)
+	}
+	lab := stats.Groups[1]
+	if lab.Shares != 3 || lab.Processes != 1 || lab.ExpectedShare != 0.75 || lab.CPUTime != usage["lab"] {
+		t.Errorf("lab stats = %+v", lab)
+	}
+	if len(stats.Users) != 1 || stats.Users[0].Name != DefaultOwner || stats.Users[0].Processes != 3 {
+		t.Errorf("users = %+v, want only the default user", stats.Users)
+	}
+}
+
+func TestParseGroupShares(t *testing.T) {
+	cases := []struct {
+		list  string
+		want  map[string]int
+		valid bool
+	}{
+		{"lab=3, course=1", map[string]int{"lab": 3, "course": 1}, true},
+		{"", map[string]int{}, true},
+		{"lab", nil, false},
+		{"lab=x", nil, false},
+		{"lab=0", nil, false},
+		{"=2", nil, false},
+	}
+	for _, tc := range cases {
+		shares, err := parseGroupShares(tc.list)
+		if (err == nil) != tc.valid {
+			t.Errorf("parseGroupShares(%q) error = %v", tc.list, err)
+			continue
+		}
+		if tc.valid && !reflect.DeepEqual(shares, tc.want) {
+			t.Errorf("parseGroupShares(%q) = %v, want %v", tc.list, shares, tc.want)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os-scheduler-backend/models"
+	"sort"
+)
+
+// DeclareGang 把一组进程声明为进程组，组内进程只在同一个时钟周期内同时占用不同的处理机时才运行。
+// 同名的进程组已存在时替换其成员；进程组须满足 checkGangs 和 checkGangPrecedence 的要求
+func (s *Scheduler) DeclareGang(name string, pids []int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if name == "" {
+		return errors.New("进程组名称不能为空")
+	}
+	pids = uniquePIDs(pids)
+	if len(pids) == 0 {
+		return errors.New("进程组至少包含一个进程")
+	}
+
+	members := make([]*models.PCB, 0, len(pids))
+	for _, pid := range pids {
+		p := s.findProcess(pid)
+		if p == nil {
+			return fmt.Errorf("找不到进程 %d", pid)
+		}
+		if p.State == models.Finished {
+			return fmt.Errorf("进程 %d 已完成", pid)
+		}
+		if p.Gang != "" && p.Gang != name {
+			return fmt.Errorf("进程 %d 已属于进程组 %s", pid, p.Gang)
+		}
+		members = append(members, p)
+	}
+
+	// 先按新成员修改再校验，不满足要求时恢复原来的成员
+	previous := make([]*models.PCB, 0)
+	for _, p := range s.allProcesses() {
+		if p.Gang == name && p.State != models.Finished {
+			previous = append(previous, p)
+			p.Gang = ""
+		}
+	}
+	for _, p := range members {
+		p.Gang = name
+	}
+	err := s.checkGangs(nil, s.ProcessorCount, s.MaxProcesses)
+	if err == nil {
+		err = s.checkGangPrecedence(name, nil)
+	}
+	if err != nil {
+		for _, p := range members {
+			p.Gang = ""
+		}
+		for _, p := range previous {
+			p.Gang = name
+		}
+		return err
+	}
+	s.eventLog.Record(OpDeclareGang, gangArgs{Name: name, PIDs: pids})
+	return nil
+}
+
+// Gangs 返回所有进程组及其成员，按名称排序
+func (s *Scheduler) Gangs() []models.Gang {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	byName := make(map[string]*models.Gang)
+	names := make([]string, 0)
+	for _, p := range s.allProcesses() {
+		if p.Gang == "" {
+			continue
+		}
+		gang, ok := byName[p.Gang]
+		if !ok {
+			gang = &models.Gang{Name: p.Gang, PIDs: make([]int, 0)}
+			byName[p.Gang] = gang
+			names = append(names, p.Gang)
+		}
+		gang.PIDs = append(gang.PIDs, p.PID)
+	}
+	sort.Strings(names)
+
+	gangs := make([]models.Gang, 0, len(names))
+	for _, name := range names {
+		sort.Ints(byName[name].PIDs)
+		gangs = append(gangs, *byName[name])
+	}
+	return gangs
+}
+
+// checkGangs 检查加入新进程后，在processorCount个处理机、道数为maxProcesses时每个进程组都能运行：
+// 组内尚未完成的进程数不超过处理机数量和道数，且各进程的亲和性允许它们同时在不同的处理机上运行
+func (s *Scheduler) checkGangs(processes []*models.PCB, processorCount, maxProcesses int) error {
+	gangs := make(map[string][]*models.PCB)
+	names := make([]string, 0)
+	for _, p := range append(s.allProcesses(), processes...) {
+		if p.Gang != "" && p.State != models.Finished {
+			if len(gangs[p.Gang]) == 0 {
+				names = append(names, p.Gang)
+			}
+			gangs[p.Gang] = append(gangs[p.Gang], p)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		members := gangs[name]
+		if size := len(members); size > processorCount {
+			return fmt.Errorf("进程组 %s 有 %d 个进程，超过处理机数量 %d", name, size, processorCount)
+		}
+		if size := len(members); size > maxProcesses {
+			return fmt.Errorf("进程组 %s 有 %d 个进程，超过道数 %d", name, size, maxProcesses)
+		}
+		if _, ok := s.matchProcessors(members, make([]bool, processorCount)); !ok {
+			return fmt.Errorf("进程组 %s 中进程的亲和性不允许它们同时在不同的处理机上运行", name)
+		}
+	}
+	return nil
+}
+
+// checkGangPrecedence 检查进程组name中没有进程要等待同组的其他进程完成，processes为尚未加入系统的新进程。
+// 组内进程要同时运行，前驱链（只经过未完成的进程）连接的两个组内进程都无法运行
+func (s *Scheduler) checkGangPrecedence(name string, processes []*models.PCB) error {
+	byPID := make(map[int]*models.PCB)
+	members := make([]*models.PCB, 0)
+	for _, p := range append(s.allProcesses(), processes...) {
+		byPID[p.PID] = p
+		if p.Gang == name && p.State != models.Finished {
+			members = append(members, p)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].PID < members[j].PID
+	})
+
+	// 从各组内进程出发沿前驱边搜索，共用访问标记，每个进程只访问一次
+	visited := make(map[int]bool)
+	var search func(root, pid int) error
+	search = func(root, pid int) error {
+		visited[pid] = true
+		for _, predPID := range byPID[pid].Predecessors {
+			pred := byPID[predPID]
+			if pred == nil || pred.State == models.Finished {
+				continue
+			}
+			if pred.Gang == name {
+				return fmt.Errorf("进程组 %s 中的进程 %d 要等待同组的进程 %d 完成", name, root, pred.PID)
+			}
+			if !visited[predPID] {
+				if err := search(root, predPID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for _, p := range members {
+		if err := search(p.PID, p.PID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNewGangs 对新进程所属的每个进程组做 checkGangs 和 checkGangPrecedence 检查
+func (s *Scheduler) checkNewGangs(processes []*models.PCB) error {
+	if err := s.checkGangs(processes, s.ProcessorCount, s.MaxProcesses); err != nil {
+		return err
+	}
+	checked := make(map[string]bool)
+	for _, p := range processes {
+		if p.Gang != "" && !checked[p.Gang] {
+			checked[p.Gang] = true
+			if err := s.checkGangPrecedence(p.Gang, processes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// gangMembers 返回进程组中尚未完成的进程，按PID排序
+func (s *Scheduler) gangMembers(name string) []*models.PCB {
+	members := make([]*models.PCB, 0)
+	for _, p := range s.allProcesses() {
+		if p.Gang == name && p.State != models.Finished {
+			members = append(members, p)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].PID < members[j].PID
+	})
+	return members
+}
+
+// gangRunning 判断进程组中尚未完成的进程是否都在运行
+func (s *Scheduler) gangRunning(name string) bool {
+	for _, p := range s.gangMembers(name) {
+		if p.State != models.Running {
+			return false
+		}
+	}
+	return true
+}
+
+// dispatchGang 为进程组分配处理机：组内进程都在就绪队列中且有足够的空闲处理机时一起运行；
+// 都在就绪队列中但空闲处理机不够时，保留可用的空闲处理机，不再分给排在后面的进程
+func (s *Scheduler) dispatchGang(name string, busy []bool) {
+	ready := make(map[int]bool, len(s.Queue.Ready))
+	for _, p := range s.Queue.Ready {
+		ready[p.PID] = true
+	}
+	members := s.gangMembers(name)
+	for _, p := range members {
+		if !ready[p.PID] || !ValidTransition(p.State, models.Running) {
+			return
+		}
+	}
+
+	cpus, ok := s.matchProcessors(members, busy)
+	if !ok {
+		// 处理机不够，保留已选中的处理机
+		for _, cpu := range cpus {
+			if cpu >= 0 {
+				busy[cpu] = true
+			}
+		}
+		return
+	}
+
+	// 组内进程都已确认可以转为运行状态，整组一起运行
+	for i, p := range members {
+		s.runOn(p, cpus[i])
+		busy[cpus[i]] = true
+		s.removeFromReady(p)
+	}
+}
+
+// matchProcessors 为进程组中的进程各挑选一个不同的空闲处理机，每个进程优先使用允许的最快的处理机，
+// 速度相同时优先使用上次运行的处理机。按增广路径求最大匹配，返回各进程分到的处理机，
+// 没有分到的为-1；所有进程都分到处理机时ok为true
+func (s *Scheduler) matchProcessors(members []*models.PCB, busy []bool) ([]int, bool) {
+	candidates := make([][]int, len(members))
+	for i, p := range members {
+		for cpu := range busy {
+			if !busy[cpu] && allowedOn(p, cpu) {
+				candidates[i] = append(candidates[i], cpu)
+			}
+		}
+		last := p.LastProcessor
+		sort.SliceStable(candidates[i], func(a, b int) bool {
+			x, y := candidates[i][a], candidates[i][b]
+			if s.speed(x) != s.speed(y) {
+				return s.faster(x, y)
+			}
+			return x == last && y != last
+		})
+	}
+
+	owner := make([]int, len(busy))
+	for cpu := range owner {
+		owner[cpu] = -1
+	}
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for _, cpu := range candidates[i] {
+			if seen[cpu] {
+				continue
+			}
+			seen[cpu] = true
+			if owner[cpu] < 0 || augment(owner[cpu], seen) {
+				owner[cpu] = i
+				return true
+			}
+		}
+		return false
+	}
+	ok := true
+	for i := range members {
+		if !augment(i, make([]bool, len(busy))) {
+			ok = false
+		}
+	}
+
+	cpus := make([]int, len(members))
+	for i := range cpus {
+		cpus[i] = -1
+	}
+	for cpu, i := range owner {
+		if i >= 0 {
+			cpus[i] = cpu
+		}
+	}
+	return cpus, ok
+}
+
+// dispatchGangs 按就绪队列的顺序为所有进程组分配处理机
+func (s *Scheduler) dispatchGangs(busy []bool) {
+	handled := make(map[string]bool)
+	for _, p := range append([]*models.PCB(nil), s.Queue.Ready...) {
+		if p.Gang != "" && !handled[p.Gang] {
+			handled[p.Gang] = true
+			s.dispatchGang(p.Gang, busy)
+		}
+	}
+}
+
+func (s *Scheduler) removeFromReady(process *models.PCB) {
+	for i, p := range s.Queue.Ready {
+		if p.PID == process.PID {
+			s.Queue.Ready = append(s.Queue.Ready[:i], s.Queue.Ready[i+1:]...)
+			break
+		}
+	}
+}
+
+// gangStats 统计各进程组的完成情况，按名称排序
+func (s *Scheduler) gangStats() []models.GangStats {
+	byName := make(map[string]*models.GangStats)
+	arrival := make(map[string]int)
+	names := make([]string, 0)
+	for _, p := range s.allProcesses() {
+		if p.Gang == "" {
+			continue
+		}
+		gs, ok := byName[p.Gang]
+		if !ok {
+			gs = &models.GangStats{Name: p.Gang, PIDs: make([]int, 0), Finished: true}
+			byName[p.Gang] = gs
+			arrival[p.Gang] = p.ArrivalTime
+			names = append(names, p.Gang)
+		}
+		gs.PIDs = append(gs.PIDs, p.PID)
+		if p.ArrivalTime < arrival[p.Gang] {
+			arrival[p.Gang] = p.ArrivalTime
+		}
+		if p.State != models.Finished {
+			gs.Finished = false
+		} else if p.FinishTime > gs.Finish {
+			gs.Finish = p.FinishTime
+		}
+	}
+	sort.Strings(names)
+
+	stats := make([]models.GangStats, 0, len(names))
+	for _, name := range names {
+		gs := byName[name]
+		sort.Ints(gs.PIDs)
+		if gs.Finished {
+			gs.Turnaround = gs.Finish - arrival[name]
+		} else {
+			gs.Finish = 0
+		}
+		stats = append(stats, *gs)
+	}
+	return stats
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"strings"
+	"testing"
+)
+
+func TestGangRunsTogether(t *testing.T) {
+	s := newTestSystem(t, nil)
+	a := addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 3, Gang: "g"})
+	b := addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 5, Gang: "g"})
+	addTestProcess(t, s, &models.PCB{Name: "solo", RequiredTime: 2, Priority: 10})
+	runUntilDone(t, s, 50)
+
+	for _, slot := range s.Timeline {
+		runsA, runsB := false, false
+		for _, pid := range slot.Processors {
+			runsA = runsA || pid == a
+			runsB = runsB || pid == b
+		}
+		if runsA != runsB && s.findProcess(a).FinishTime > slot.Tick {
+			t.Fatalf("tick %d runs only part of the gang: %v", slot.Tick, slot.Processors)
+		}
+	}
+}
+
+func TestGangRejectsUnrunnableGroups(t *testing.T) {
+	cases := []struct {
+		name  string
+		cfg   func(*models.SystemConfig)
+		batch []models.BatchProcess
+		want  string
+	}{
+		{
+			name: "larger than max processes",
+			cfg:  func(cfg *models.SystemConfig) { cfg.ProcessorCount = 4; cfg.MaxProcesses = 2 },
+			batch: []models.BatchProcess{
+				{ID: "a", RequiredTime: 1, Gang: "g"},
+				{ID: "b", RequiredTime: 1, Gang: "g"},
+				{ID: "c", RequiredTime: 1, Gang: "g"},
+			},
+			want: "超过道数",
+		},
+		{
+			name: "larger than processors",
+			batch: []models.BatchProcess{
+				{ID: "a", RequiredTime: 1, Gang: "g"},
+				{ID: "b", RequiredTime: 1, Gang: "g"},
+				{ID: "c", RequiredTime: 1, Gang: "g"},
+			},
+			want: "超过处理机数量",
+		},
+		{
+			name: "direct predecessor",
+			batch: []models.BatchProcess{
+				{ID: "a", RequiredTime: 1, Gang: "g"},
+				{ID: "b", RequiredTime: 1, Gang: "g", Predecessors: []string{"a"}},
+			},
+			want: "要等待同组的进程",
+		},
+		{
+			name: "indirect predecessor",
+			batch: []models.BatchProcess{
+				{ID: "a", RequiredTime: 1, Gang: "g"},
+				{ID: "x", RequiredTime: 1, Predecessors: []string{"a"}},
+				{ID: "b", RequiredTime: 1, Gang: "g", Predecessors: []string{"x"}},
+			},
+			want: "要等待同组的进程",
+		},
+		{
+			name: "members pinned to one processor",
+			batch: []models.BatchProcess{
+				{ID: "a", RequiredTime: 1, Gang: "g", AffinityMask: 0b01},
+				{ID: "b", RequiredTime: 1, Gang: "g", AffinityMask: 0b01},
+			},
+			want: "亲和性",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestSystem(t, tc.cfg)
+			_, _, err := s.AddProcessBatch(tc.batch)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("AddProcessBatch error = %v, want %q", err, tc.want)
+			}
+
+			// 先提交不带进程组的进程，再声明进程组
+			plain := make([]models.BatchProcess, len(tc.batch))
+			for i, spec := range tc.batch {
+				spec.Gang = ""
+				plain[i] = spec
+			}
+			mapping, _, err := s.AddProcessBatch(plain)
+			if err != nil {
+				t.Fatalf("AddProcessBatch without gang: %v", err)
+			}
+			pids := make([]int, 0)
+			for _, spec := range tc.batch {
+				if spec.Gang != "" {
+					pids = append(pids, mapping[spec.ID])
+				}
+			}
+			err = s.DeclareGang("g", pids)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("DeclareGang error = %v, want %q", err, tc.want)
+			}
+			for _, p := range s.allProcesses() {
+				if p.Gang != "" {
+					t.Errorf("process %d kept gang %q after a rejected declaration", p.PID, p.Gang)
+				}
+			}
+		})
+	}
+}
+
+func TestGangChecksOnReconfiguration(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.ProcessorCount = 2 })
+	a := addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 4, Gang: "g", AffinityMask: 0b11})
+	addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 4, Gang: "g"})
+
+	if err := s.SetProcessorCount(1); err == nil {
+		t.Error("SetProcessorCount shrank below the gang size")
+	}
+	cfg := s.Config()
+	cfg.MaxProcesses = 1
+	if err := s.Configure(cfg, false); err == nil {
+		t.Error("Configure lowered max processes below the gang size")
+	}
+	if err := s.SetAffinity(a, 0b01); err != nil {
+		t.Fatalf("SetAffinity leaving a distinct processor for each member: %v", err)
+	}
+	b := s.findProcess(a + 1)
+	if err := s.SetAffinity(b.PID, 0b01); err == nil {
+		t.Error("SetAffinity pinned both gang members to CPU0")
+	}
+	if b.AffinityMask != 0 {
+		t.Errorf("rejected SetAffinity changed the mask to %b", b.AffinityMask)
+	}
+}
+
+func TestGangMatchesAffinity(t *testing.T) {
+	// 贪心地把处理机0分给a时b无法运行，需要把a换到处理机1
+	s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.ProcessorCount = 2 })
+	addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 2, Gang: "g", AffinityMask: 0b11})
+	addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 2, Gang: "g", AffinityMask: 0b01})
+	runUntilDone(t, s, 20)
+	if s.Clock != 2 {
+		t.Errorf("makespan = %d, want 2", s.Clock)
+	}
+}
+
+func TestGangsAdmittedFromBackupTogether(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxProcesses = 2
+	workload := &models.Workload{Processes: []models.WorkloadProcess{
+		{ID: "a1", Burst: 2, Gang: "a"},
+		{ID: "b1", Burst: 2, Gang: "b"},
+		{ID: "a2", Burst: 2, Gang: "a"},
+		{ID: "b2", Burst: 2, Gang: "b"},
+	}}
+	result, err := RunWorkload(workload, cfg, 100)
+	if err != nil {
+		t.Fatalf("RunWorkload: %v", err)
+	}
+	for _, gang := range result.Stats.Gangs {
+		if !gang.Finished {
+			t.Errorf("gang %s did not finish", gang.Name)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os-scheduler-backend/models"
+)
+
+// 单次生成的最大进程数和前驱边总数
+const (
+	MaxGeneratedProcesses = 10000
+	MaxGeneratedEdges     = 100000
+)
+
+// 未指定分布时使用的默认值
+var (
+	defaultBurst    = models.Distribution{Kind: models.DistributionExponential, Min: 1, Max: 50, Mean: 5}
+	defaultPriority = models.Distribution{Kind: models.DistributionUniform, Min: 1, Max: 10}
+	defaultMemory   = models.Distribution{Kind: models.DistributionUniform, Min: 16, Max: 512}
+)
+
+// GenerateWorkload 按配置生成合成负载。到达时间服从泊松过程，
+// 前驱只会从先到达的进程中选取，因此生成的前驱图总是无环的；
+// 前驱边的总数不超过 MaxGeneratedEdges，达到上限后不再添加
+func GenerateWorkload(cfg models.GeneratorConfig) (*models.Workload, error) {
+	if cfg.Count <= 0 || cfg.Count > MaxGeneratedProcesses {
+		return nil, fmt.Errorf("进程数必须在1到%d之间", MaxGeneratedProcesses)
+	}
+	if cfg.ArrivalRate < 0 {
+		return nil, errors.New("到达率不能为负数")
+	}
+	if cfg.DAGDensity < 0 || cfg.DAGDensity > 1 {
+		return nil, errors.New("前驱图密度必须在0到1之间")
+	}
+	if float64(cfg.Count)*float64(cfg.Count-1)/2*cfg.DAGDensity > MaxGeneratedEdges {
+		return nil, fmt.Errorf("前驱图的期望边数超过%d，请减少进程数或降低密度", MaxGeneratedEdges)
+	}
+
+	burst := withDefault(cfg.Burst, defaultBurst)
+	priority := withDefault(cfg.Priority, defaultPriority)
+	memory := withDefault(cfg.Memory, defaultMemory)
+	for _, d := range []models.Distribution{burst, priority, memory} {
+		if err := validateDistribution(d); err != nil {
+			return nil, err
+		}
+	}
+	if burst.Min < 1 {
+		burst.Min = 1
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = fmt.Sprintf("generated-%d", cfg.Seed)
+	}
+	workload := &models.Workload{Name: name, Processes: make([]models.WorkloadProcess, 0, cfg.Count)}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	clock := 0.0
+	edges := 0
+	for i := 0; i < cfg.Count; i++ {
+		if cfg.ArrivalRate > 0 && i > 0 {
+			clock += rng.ExpFloat64() / cfg.ArrivalRate
+		}
+
+		id := fmt.Sprintf("p%d", i+1)
+		process := models.WorkloadProcess{
+			ID:           id,
+			Name:         id,
+			Arrival:      int(clock),
+			Burst:        sample(rng, burst),
+			Priority:     sample(rng, priority),
+			Memory:       sample(rng, memory),
+			Predecessors: make([]string, 0),
+		}
+		for j := 0; j < i; j++ {
+			if cfg.DAGDensity > 0 && rng.Float64() < cfg.DAGDensity && edges < MaxGeneratedEdges {
+				process.Predecessors = append(process.Predecessors, workload.Processes[j].ID)
+				edges++
+			}
+		}
+		workload.Processes = append(workload.Processes, process)
+	}
+	return workload, nil
+}
+
+func withDefault(d, fallback models.Distribution) models.Distribution {
+	if d.Kind == "" {
+		return fallback
+	}
+	return d
+}
+
+func validateDistribution(d models.Distribution) error {
+	if d.Max != 0 && d.Max < d.Min {
+		return errors.New("分布的上限不能小于下限")
+	}
+	switch d.Kind {
+	case models.DistributionUniform:
+		if d.Max == 0 {
+			return errors.New("均匀分布必须指定上限")
+		}
+	case models.DistributionExponential:
+		if d.Mean <= 0 {
+			return errors.New("指数分布的均值必须大于0")
+		}
+	case models.DistributionBimodal:
+		if d.Mean <= 0 || d.LongMean <= 0 || d.LongRatio < 0 || d.LongRatio > 1 {
+			return errors.New("双峰分布的两个均值必须大于0，长作业比例必须在0到1之间")
+		}
+	default:
+		return fmt.Errorf("不支持的分布类型 %s", d.Kind)
+	}
+	return nil
+}
+
+// sample 从分布中抽取一个整数并限制在[Min, Max]内
+func sample(rng *rand.Rand, d models.Distribution) int {
+	var value float64
+	switch d.Kind {
+	case models.DistributionUniform:
+		return d.Min + rng.Intn(d.Max-d.Min+1)
+	case models.DistributionExponential:
+		value = rng.ExpFloat64() * d.Mean
+	case models.DistributionBimodal:
+		mean := d.Mean
+		if rng.Float64() < d.LongRatio {
+			mean = d.LongMean
+		}
+		value = rng.ExpFloat64() * mean
+	}
+
+	n := int(math.Round(value))
+	if n < d.Min {
+		n = d.Min
+	}
+	if d.Max != 0 && n > d.Max {
+		n = d.Max
+	}
+	return n
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// edgeCount 返回负载中前驱边的总数
+func edgeCount(workload *models.Workload) int {
+	edges := 0
+	for _, p := range workload.Processes {
+		edges += len(p.Predecessors)
+	}
+	return edges
+}
+
+func TestGenerateWorkloadIsDeterministic(t *testing.T) {
+	cfg := models.GeneratorConfig{Count: 50, ArrivalRate: 0.5, DAGDensity: 0.1, Seed: 7}
+	first, err := GenerateWorkload(cfg)
+	if err != nil {
+		t.Fatalf("GenerateWorkload: %v", err)
+	}
+	second, err := GenerateWorkload(cfg)
+	if err != nil {
+		t.Fatalf("GenerateWorkload: %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Error("the same seed generated different workloads")
+	}
+	if edgeCount(first) == 0 {
+		t.Error("no predecessors generated with density 0.1")
+	}
+}
+
+func TestGenerateWorkloadLimitsEdges(t *testing.T) {
+	_, err := GenerateWorkload(models.GeneratorConfig{Count: MaxGeneratedProcesses, DAGDensity: 1})
+	if err == nil || !strings.Contains(err.Error(), "边数") {
+		t.Fatalf("GenerateWorkload error = %v, want an edge limit error", err)
+	}
+
+	// 期望边数在上限以内时按密度生成全部的边
+	workload, err := GenerateWorkload(models.GeneratorConfig{Count: 447, DAGDensity: 1})
+	if err != nil {
+		t.Fatalf("GenerateWorkload: %v", err)
+	}
+	if n := edgeCount(workload); n != 447*446/2 {
+		t.Errorf("edges = %d, want %d", n, 447*446/2)
+	}
+}
+
+func TestSubmitLargeGeneratedWorkload(t *testing.T) {
+	workload, err := GenerateWorkload(models.GeneratorConfig{
+		Count:      MaxGeneratedProcesses,
+		DAGDensity: float64(MaxGeneratedEdges) / (MaxGeneratedProcesses * (MaxGeneratedProcesses - 1) / 2),
+		Seed:       1,
+	})
+	if err != nil {
+		t.Fatalf("GenerateWorkload: %v", err)
+	}
+	s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.TotalMemory = 1 << 30 })
+	if _, err := s.SubmitWorkload(workload); err != nil {
+		t.Fatalf("SubmitWorkload: %v", err)
+	}
+	if n := len(s.allProcesses()); n != MaxGeneratedProcesses {
+		t.Fatalf("%d processes after submit, want %d", n, MaxGeneratedProcesses)
+	}
+	for i := 0; i < 5; i++ {
+		s.Schedule()
+	}
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"fmt"
+	"os-scheduler-backend/models"
+	"sort"
+	"strings"
+)
+
+// PrecedenceGraph 导出当前所有进程（包括已完成进程）构成的前驱图，并计算关键路径
+func (s *Scheduler) PrecedenceGraph() *models.PrecedenceGraph {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	processes := s.allProcesses()
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].PID < processes[j].PID
+	})
+
+	byPID := make(map[int]*models.PCB, len(processes))
+	for _, p := range processes {
+		byPID[p.PID] = p
+	}
+
+	graph := &models.PrecedenceGraph{
+		Nodes:        make([]models.GraphNode, 0, len(processes)),
+		Edges:        make([]models.GraphEdge, 0),
+		CriticalPath: make([]int, 0),
+	}
+	for _, p := range processes {
+		for _, predPID := range p.Predecessors {
+			if _, ok := byPID[predPID]; ok {
+				graph.Edges = append(graph.Edges, models.GraphEdge{From: predPID, To: p.PID})
+			}
+		}
+	}
+
+	// 关键路径：以总运行时间为权重的最长路径
+	length := make(map[int]int, len(processes))
+	via := make(map[int]int, len(processes))
+	var longest func(pid int) int
+	longest = func(pid int) int {
+		if l, ok := length[pid]; ok {
+			return l
+		}
+		p := byPID[pid]
+		best, bestPred := 0, 0
+		for _, predPID := range p.Predecessors {
+			if _, ok := byPID[predPID]; !ok {
+				continue
+			}
+			if l := longest(predPID); l > best {
+				best, bestPred = l, predPID
+			}
+		}
+		length[pid] = best + p.TotalRequiredTime
+		via[pid] = bestPred
+		return length[pid]
+	}
+
+	end := 0
+	for _, p := range processes {
+		if l := longest(p.PID); l > graph.CriticalPathLength {
+			graph.CriticalPathLength, end = l, p.PID
+		}
+	}
+	onPath := make(map[int]bool)
+	for pid := end; pid != 0; pid = via[pid] {
+		graph.CriticalPath = append([]int{pid}, graph.CriticalPath...)
+		onPath[pid] = true
+	}
+
+	for _, p := range processes {
+		graph.Nodes = append(graph.Nodes, models.GraphNode{
+			PID:            p.PID,
+			Name:           p.Name,
+			State:          p.State,
+			TotalTime:      p.TotalRequiredTime,
+			RemainingTime:  p.RequiredTime,
+			OnCriticalPath: onPath[p.PID],
+		})
+	}
+	return graph
+}
+
+// 各进程状态在DOT图中的填充颜色
+var stateColors = map[models.ProcessState]string{
+	models.Ready:            "lightblue",
+	models.Running:          "palegreen",
+	models.Waiting:          "khaki",
+	models.Finished:         "lightgray",
+	models.SuspendedReady:   "lightpink",
+	models.SuspendedBlocked: "plum",
+}
+
+// RenderDOT 将前驱图渲染为Graphviz DOT格式，关键路径以红色标出
+func RenderDOT(graph *models.PrecedenceGraph) string {
+	pathEdges := make(map[models.GraphEdge]bool)
+	for i := 1; i < len(graph.CriticalPath); i++ {
+		pathEdges[models.GraphEdge{From: graph.CriticalPath[i-1], To: graph.CriticalPath[i]}] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph precedence {\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, style=filled];\n")
+	for _, node := range graph.Nodes {
+		color, ok := stateColors[node.State]
+		if !ok {
+			color = "white"
+		}
+		attrs := fmt.Sprintf("label=%q, fillcolor=%s", fmt.Sprintf("%d: %s\n%s %d/%d", node.PID, node.Name, node.State, node.RemainingTime, node.TotalTime), color)
+		if node.OnCriticalPath {
+			attrs += ", color=red, penwidth=2"
+		}
+		fmt.Fprintf(&b, "\tp%d [%s];\n", node.PID, attrs)
+	}
+	for _, edge := range graph.Edges {
+		if pathEdges[edge] {
+			fmt.Fprintf(&b, "\tp%d -> p%d [color=red, penwidth=2];\n", edge.From, edge.To)
+		} else {
+			fmt.Fprintf(&b, "\tp%d -> p%d;\n", edge.From, edge.To)
+		}
+	}
+	fmt.Fprintf(&b, "\tlabel=%q;\n", fmt.Sprintf("critical path length: %d", graph.CriticalPathLength))
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// diamondSystem 添加菱形前驱图 a→b,c→d 和一个独立的进程 e
+func diamondSystem(t *testing.T) (*Scheduler, map[string]int) {
+	t.Helper()
+	s := newTestSystem(t, nil)
+	pids := make(map[string]int)
+	pids["a"] = addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 2})
+	pids["b"] = addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 5, Predecessors: []int{pids["a"]}})
+	pids["c"] = addTestProcess(t, s, &models.PCB{Name: "c", RequiredTime: 1, Predecessors: []int{pids["a"]}})
+	pids["d"] = addTestProcess(t, s, &models.PCB{Name: "d", RequiredTime: 3, Predecessors: []int{pids["b"], pids["c"]}})
+	pids["e"] = addTestProcess(t, s, &models.PCB{Name: "e", RequiredTime: 4})
+	return s, pids
+}
+
+func TestPrecedenceGraphCriticalPath(t *testing.T) {
+	s, pids := diamondSystem(t)
+	graph := s.PrecedenceGraph()
+
+	if len(graph.Nodes) != 5 {
+		t.Fatalf("%d nodes, want 5", len(graph.Nodes))
+	}
+	wantEdges := []models.GraphEdge{
+		{From: pids["a"], To: pids["b"]},
+		{From: pids["a"], To: pids["c"]},
+		{From: pids["b"], To: pids["d"]},
+		{From: pids["c"], To: pids["d"]},
+	}
+	if !reflect.DeepEqual(graph.Edges, wantEdges) {
+		t.Errorf("edges = %v, want %v", graph.Edges, wantEdges)
+	}
+	if want := []int{pids["a"], pids["b"], pids["d"]}; !reflect.DeepEqual(graph.CriticalPath, want) {
+		t.Errorf("critical path = %v, want %v", graph.CriticalPath, want)
+	}
+	if graph.CriticalPathLength != 10 {
+		t.Errorf("critical path length = %d, want 10", graph.CriticalPathLength)
+	}
+	for _, node := range graph.Nodes {
+		want := node.PID == pids["a"] || node.PID == pids["b"] || node.PID == pids["d"]
+		if node.OnCriticalPath != want {
+			t.Errorf("node %s on critical path = %v, want %v", node.Name, node.OnCriticalPath, want)
+		}
+	}
+}
+
+func TestPrecedenceGraphKeepsFinishedProcesses(t *testing.T) {
+	s, pids := diamondSystem(t)
+	for s.findProcess(pids["a"]).State != models.Finished {
+		s.Schedule()
+	}
+	graph := s.PrecedenceGraph()
+	for _, node := range graph.Nodes {
+		if node.PID == pids["a"] && (node.State != models.Finished || node.RemainingTime != 0 || node.TotalTime != 2) {
+			t.Errorf("finished node = %+v", node)
+		}
+	}
+	if graph.CriticalPathLength != 10 {
+		t.Errorf("critical path length = %d after a finished, want 10", graph.CriticalPathLength)
+	}
+}
+
+func TestRenderDOT(t *testing.T) {
+	s, pids := diamondSystem(t)
+	if pids["a"] != 1 || pids["b"] != 2 || pids["c"] != 3 || pids["e"] != 5 {
+		t.Fatalf("unexpected PIDs %v", pids)
+	}
+	dot := RenderDOT(s.PrecedenceGraph())
+
+	if !strings.HasPrefix(dot, "digraph precedence {\n") || !strings.HasSuffix(dot, "}\n") {
+		t.Fatalf("not a DOT digraph:\n%s", dot)
+	}
+	for _, want := range []string{
+		`p1 [label="1: a\nready 2/2", fillcolor=lightblue, color=red, penwidth=2];`,
+		`p5 [label="5: e\nready 4/4", fillcolor=lightblue];`,
+		"p1 -> p2 [color=red, penwidth=2];",
+		"p1 -> p3;",
+		`label="critical path length: 10";`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT output lacks %q:\n%s", want, dot)
+		}
+	}
+}
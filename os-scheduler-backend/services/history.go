@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"os-scheduler-backend/models"
+)
+
+// DefaultHistorySize 默认保留的历史快照数量
+const DefaultHistorySize = 100
+
+// historyEntry 一次调度前的状态。时间线和时间线事件只会追加，快照中不保存它们，
+// 只记录当时的长度，回退时截断到该长度即可，避免每次调度都复制整条时间线
+type historyEntry struct {
+	snap     *models.Snapshot
+	timeline int
+	events   int
+}
+
+// snapshotRing 固定容量的快照环形缓冲区，写满后覆盖最旧的快照
+type snapshotRing struct {
+	items []historyEntry
+	start int // 最旧快照的位置
+	size  int
+}
+
+func newSnapshotRing(capacity int) *snapshotRing {
+	return &snapshotRing{items: make([]historyEntry, capacity)}
+}
+
+// enabled 判断是否需要保存历史快照
+func (r *snapshotRing) enabled() bool {
+	return len(r.items) > 0
+}
+
+func (r *snapshotRing) push(entry historyEntry) {
+	if !r.enabled() {
+		return
+	}
+	if r.size < len(r.items) {
+		r.items[(r.start+r.size)%len(r.items)] = entry
+		r.size++
+		return
+	}
+	r.items[r.start] = entry
+	r.start = (r.start + 1) % len(r.items)
+}
+
+// pop 取出最新的快照，缓冲区为空时返回零值
+func (r *snapshotRing) pop() historyEntry {
+	if r.size == 0 {
+		return historyEntry{}
+	}
+	r.size--
+	i := (r.start + r.size) % len(r.items)
+	entry := r.items[i]
+	r.items[i] = historyEntry{}
+	return entry
+}
+
+func (r *snapshotRing) clear() {
+	for i := range r.items {
+		r.items[i] = historyEntry{}
+	}
+	r.start, r.size = 0, 0
+}
+
+// SetHistorySize 设置可回退的最大步数，已有的历史记录会被清空
+func (s *Scheduler) SetHistorySize(size int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if size < 0 {
+		size = 0
+	}
+	s.history = newSnapshotRing(size)
+	s.eventLog.Record(OpSetHistorySize, historySizeArgs{Size: size})
+}
+
+// HistoryLength 返回当前可回退的步数
+func (s *Scheduler) HistoryLength() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.history.size
+}
+
+// Rewind 回退steps次调度，恢复到对应调度执行前的进程队列和内存布局。
+// 历史记录不足时回退到最早的记录，返回实际回退的步数
+func (s *Scheduler) Rewind(steps int) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if steps <= 0 {
+		return 0, errors.New("回退步数必须大于0")
+	}
+	if s.history.size == 0 {
+		return 0, errors.New("没有可回退的历史记录")
+	}
+
+	var entry historyEntry
+	rewound := 0
+	for rewound < steps && s.history.size > 0 {
+		entry = s.history.pop()
+		rewound++
+	}
+	snap := *entry.snap
+	snap.Timeline = s.Timeline[:entry.timeline]
+	snap.Events = s.Events[:entry.events]
+	if err := s.restore(&snap); err != nil {
+		return 0, err
+	}
+	s.eventLog.Record(OpRewind, rewindArgs{Steps: steps})
+	return rewound, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"reflect"
+	"testing"
+)
+
+func TestRewindRestoresTimelineAndEvents(t *testing.T) {
+	s := newTestSystem(t, nil)
+	addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 6, MemorySize: 10})
+	addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 4, MemorySize: 10})
+	for i := 0; i < 3; i++ {
+		s.Schedule()
+	}
+	before := s.Snapshot()
+	for i := 0; i < 5; i++ {
+		s.Schedule()
+	}
+
+	rewound, err := s.Rewind(5)
+	if err != nil || rewound != 5 {
+		t.Fatalf("Rewind(5) = %d, %v", rewound, err)
+	}
+	after := s.Snapshot()
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("state after rewind differs from state before scheduling\nbefore: %+v\nafter:  %+v", before, after)
+	}
+
+	// 回退后继续调度，时间线不能与回退前残留的部分共用底层数组
+	s.Schedule()
+	if len(s.Timeline) != len(before.Timeline)+1 {
+		t.Errorf("timeline length = %d, want %d", len(s.Timeline), len(before.Timeline)+1)
+	}
+}
+
+func TestHistoryDoesNotCopyTimeline(t *testing.T) {
+	s := newTestSystem(t, nil)
+	addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 50, MemorySize: 10})
+	for i := 0; i < 20; i++ {
+		s.Schedule()
+	}
+	for i := 0; i < s.history.size; i++ {
+		entry := s.history.items[(s.history.start+i)%len(s.history.items)]
+		if entry.snap.Timeline != nil || entry.snap.Events != nil {
+			t.Fatalf("history entry %d holds a copy of the timeline", i)
+		}
+		if entry.timeline > len(s.Timeline) || entry.events > len(s.Events) {
+			t.Fatalf("history entry %d records lengths beyond the current timeline", i)
+		}
+	}
+}
+
+func TestDisabledHistoryKeepsNoSnapshots(t *testing.T) {
+	s := newTestSystem(t, nil)
+	s.SetHistorySize(0)
+	addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 5, MemorySize: 10})
+	s.Schedule()
+	if s.history.size != 0 {
+		t.Errorf("history holds %d snapshots, want none", s.history.size)
+	}
+	if _, err := s.Rewind(1); err == nil {
+		t.Error("Rewind succeeded without history")
+	}
+}
@@ -0,0 +1,304 @@
+package services
+
+import (
+	"fmt"
+	"os-scheduler-backend/models"
+	"sort"
+)
+
+// ValidLockProtocol 判断是否为支持的锁协议
+func ValidLockProtocol(protocol models.LockProtocol) bool {
+	switch protocol {
+	case models.LockNone, models.LockInheritance, models.LockCeiling:
+		return true
+	}
+	return false
+}
+
+// validateSections 按开始位置排序进程的临界区并校验：锁名不能为空，临界区不能重叠或嵌套，
+// 也不能超出进程的运行时间。同一时刻最多持有一个锁，因此不会发生死锁
+func validateSections(id string, sections []models.CriticalSection, burst int) error {
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].Start < sections[j].Start
+	})
+	end := 0
+	for _, cs := range sections {
+		switch {
+		case cs.Lock == "":
+			return fmt.Errorf("进程 %s 的临界区缺少锁名", id)
+		case cs.Start < 0 || cs.Length <= 0:
+			return fmt.Errorf("进程 %s 的临界区 %s 的开始位置不能为负数，长度必须大于0", id, cs.Lock)
+		case cs.Start < end:
+			return fmt.Errorf("进程 %s 的临界区 %s 与前一个临界区重叠", id, cs.Lock)
+		case cs.Start+cs.Length > burst:
+			return fmt.Errorf("进程 %s 的临界区 %s 超出运行时间", id, cs.Lock)
+		}
+		end = cs.Start + cs.Length
+	}
+	return nil
+}
+
+// progress 返回进程已完成的工作量
+func progress(p *models.PCB) int {
+	return p.TotalRequiredTime - p.RequiredTime
+}
+
+// currentSection 返回进程所在或下一个要进入的临界区，没有时返回nil
+func currentSection(p *models.PCB) *models.CriticalSection {
+	done := progress(p)
+	for i := range p.CriticalSections {
+		if cs := &p.CriticalSections[i]; done < cs.Start+cs.Length {
+			return cs
+		}
+	}
+	return nil
+}
+
+// sectionLimit 返回进程在这个时钟周期最多推进到的位置：未持有锁时停在下一个临界区的开始处，
+// 以便下一个时钟周期申请锁；持有锁时停在临界区的结束处。速度大于1的处理机上的进程也不会越过临界区。
+// cs为 enterSection 返回的临界区，为空时没有限制，返回-1
+func sectionLimit(p *models.PCB, cs *models.CriticalSection) int {
+	switch {
+	case cs == nil:
+		return -1
+	case p.HeldLock == cs.Lock:
+		return cs.Start + cs.Length
+	default:
+		return cs.Start
+	}
+}
+
+// lockHolder 返回持有锁的进程，锁空闲时返回nil
+func (s *Scheduler) lockHolder(name string) *models.PCB {
+	for _, p := range s.allProcesses() {
+		if p.HeldLock == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// enterSection 在运行中的进程推进之前调用，返回进程所在或下一个要进入的临界区。
+// 进程执行到临界区开始处时申请锁：锁空闲时获得锁继续运行；锁被其他进程持有时，
+// 这个时钟周期用于申请锁而不推进，之后进程阻塞进入等待队列，blocked为true
+func (s *Scheduler) enterSection(p *models.PCB) (cs *models.CriticalSection, blocked bool) {
+	cs = currentSection(p)
+	if cs == nil || p.HeldLock != "" || progress(p) < cs.Start {
+		return cs, false
+	}
+	holder := s.lockHolder(cs.Lock)
+	if holder == nil {
+		p.HeldLock = cs.Lock
+		return cs, false
+	}
+
+	// 不能转为等待状态时进程留在运行队列中，这个时钟周期同样只用于申请锁
+	if err := s.transition(p, models.Waiting, s.Clock+1, "等待锁 "+cs.Lock); err != nil {
+		return cs, true
+	}
+	s.removeFromRunning(p)
+	p.BlockedOn = cs.Lock
+	p.ProcessorID = -1
+	p.QuantumUsed = 0
+	s.Queue.Waiting = append(s.Queue.Waiting, p)
+	s.Events = append(s.Events, models.TimelineEvent{
+		Tick:    s.Clock + 1,
+		Type:    models.TimelineLockWait,
+		Message: fmt.Sprintf("进程 %s 等待锁 %s，持有者为进程 %s", p.Name, cs.Lock, holder.Name),
+		PIDs:    []int{p.PID, holder.PID},
+	})
+	return cs, true
+}
+
+// releaseLock 释放进程持有的锁并恢复其基础优先数。等待者中优先数最大的进程直接获得锁，
+// 优先数相同时先阻塞的进程优先，获得锁的进程回到就绪队列。挂起的进程不获得锁；
+// 只有挂起的进程在等待该锁时，它们都转为挂起就绪，恢复运行后重新申请
+func (s *Scheduler) releaseLock(p *models.PCB) {
+	name := p.HeldLock
+	p.HeldLock = ""
+	if p.Boost != 0 {
+		p.Priority -= p.Boost
+		p.Boost = 0
+		s.Events = append(s.Events, models.TimelineEvent{
+			Tick:    s.Clock + 1,
+			Type:    models.TimelinePriorityRestore,
+			Message: fmt.Sprintf("进程 %s 释放锁 %s，优先数恢复为 %d", p.Name, name, p.Priority),
+			PIDs:    []int{p.PID},
+		})
+	}
+
+	next := -1
+	for i, q := range s.Queue.Waiting {
+		if q.BlockedOn == name && (next < 0 || q.Priority > s.Queue.Waiting[next].Priority) {
+			next = i
+		}
+	}
+	if next < 0 {
+		for _, q := range s.Queue.Suspended {
+			if q.BlockedOn == name && s.transition(q, models.SuspendedReady, s.Clock+1, "锁 "+name+" 已释放") == nil {
+				q.BlockedOn = ""
+			}
+		}
+		return
+	}
+	q := s.Queue.Waiting[next]
+	if s.transition(q, models.Ready, s.Clock+1, "获得锁 "+name) != nil {
+		return
+	}
+	s.Queue.Waiting = append(s.Queue.Waiting[:next], s.Queue.Waiting[next+1:]...)
+	q.BlockedOn = ""
+	q.HeldLock = name
+	s.enqueueReady(q)
+}
+
+// lockCeiling 返回锁的天花板优先数：会使用该锁的未完成进程（包括尚未到达的进程）的最大基础优先数
+func (s *Scheduler) lockCeiling(name string) int {
+	ceiling, found := 0, false
+	for _, p := range s.allProcesses() {
+		if p.State == models.Finished {
+			continue
+		}
+		for _, cs := range p.CriticalSections {
+			if cs.Lock == name && (!found || p.Priority-p.Boost > ceiling) {
+				ceiling, found = p.Priority-p.Boost, true
+			}
+		}
+	}
+	return ceiling
+}
+
+// applyLockProtocol 按锁协议重新计算持有锁的进程的有效优先数。基础优先数为优先数减去提升量；
+// 优先级继承提升到等待该锁的进程中的最大优先数，优先级天花板提升到锁的天花板优先数，
+// 都不低于基础优先数。有效优先数变化时记录事件
+func (s *Scheduler) applyLockProtocol() {
+	for _, p := range s.allProcesses() {
+		if p.HeldLock == "" {
+			continue
+		}
+		base := p.Priority - p.Boost
+		target := base
+		var from *models.PCB
+		switch s.LockProtocol {
+		case models.LockInheritance:
+			for _, q := range s.Queue.Waiting {
+				if q.BlockedOn == p.HeldLock && q.Priority > target {
+					target, from = q.Priority, q
+				}
+			}
+		case models.LockCeiling:
+			if ceiling := s.lockCeiling(p.HeldLock); ceiling > target {
+				target = ceiling
+			}
+		}
+
+		switch {
+		case target > p.Priority && from != nil:
+			s.Events = append(s.Events, models.TimelineEvent{
+				Tick:    s.Clock,
+				Type:    models.TimelinePriorityBoost,
+				Message: fmt.Sprintf("进程 %s 持有锁 %s，继承进程 %s 的优先数 %d", p.Name, p.HeldLock, from.Name, target),
+				PIDs:    []int{p.PID, from.PID},
+			})
+		case target > p.Priority:
+			s.Events = append(s.Events, models.TimelineEvent{
+				Tick:    s.Clock,
+				Type:    models.TimelinePriorityBoost,
+				Message: fmt.Sprintf("进程 %s 持有锁 %s，优先数提升到天花板 %d", p.Name, p.HeldLock, target),
+				PIDs:    []int{p.PID},
+			})
+		case target < p.Priority:
+			s.Events = append(s.Events, models.TimelineEvent{
+				Tick:    s.Clock,
+				Type:    models.TimelinePriorityRestore,
+				Message: fmt.Sprintf("进程 %s 持有锁 %s，优先数降为 %d", p.Name, p.HeldLock, target),
+				PIDs:    []int{p.PID},
+			})
+		}
+		p.Boost = target - base
+		p.Priority = target
+	}
+}
+
+// countLockWaits 累计因等待锁阻塞的时钟周期数
+func (s *Scheduler) countLockWaits() {
+	for _, p := range s.Queue.Waiting {
+		if p.BlockedOn != "" {
+			p.LockWait++
+		}
+	}
+}
+
+// Locks 返回进程使用或持有的所有锁的状态，按锁名排序
+func (s *Scheduler) Locks() []models.Lock {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	byName := make(map[string]*models.Lock)
+	names := make([]string, 0)
+	lock := func(name string) *models.Lock {
+		l, ok := byName[name]
+		if !ok {
+			l = &models.Lock{Name: name, Waiters: make([]int, 0), Ceiling: s.lockCeiling(name)}
+			byName[name] = l
+			names = append(names, name)
+		}
+		return l
+	}
+	for _, p := range s.allProcesses() {
+		if p.State != models.Finished {
+			for _, cs := range p.CriticalSections {
+				lock(cs.Lock)
+			}
+		}
+		if p.HeldLock != "" {
+			lock(p.HeldLock).Holder = p.PID
+		}
+	}
+	for _, p := range s.Queue.Waiting {
+		if p.BlockedOn != "" {
+			lock(p.BlockedOn).Waiters = append(lock(p.BlockedOn).Waiters, p.PID)
+		}
+	}
+	sort.Strings(names)
+
+	locks := make([]models.Lock, 0, len(names))
+	for _, name := range names {
+		locks = append(locks, *byName[name])
+	}
+	return locks
+}
+
+// PathfinderWorkload 返回重现火星探路者号优先级反转的负载：低优先数的气象任务持有总线锁时，
+// 高优先数的总线管理任务到达并等待该锁，随后到达的中优先数通信任务抢占气象任务，
+// 不调整优先数时总线管理任务被通信任务间接阻塞
+func PathfinderWorkload() *models.Workload {
+	bus := "bus"
+	return &models.Workload{
+		Name: "pathfinder",
+		Processes: []models.WorkloadProcess{
+			{ID: "meteo", Name: "meteo", Arrival: 0, Burst: 5, Priority: 1, Memory: 64,
+				CriticalSections: []models.CriticalSection{{Lock: bus, Start: 1, Length: 3}}},
+			{ID: "bus_dist", Name: "bus_dist", Arrival: 2, Burst: 3, Priority: 30, Memory: 64,
+				CriticalSections: []models.CriticalSection{{Lock: bus, Start: 0, Length: 2}}},
+			{ID: "comms", Name: "comms", Arrival: 3, Burst: 6, Priority: 20, Memory: 64},
+		},
+	}
+}
+
+// LoadPathfinder 重置系统为单处理机、动态优先数调度和指定的锁协议，然后提交火星探路者号场景的负载。
+// 其余系统参数保持不变，返回负载内ID到PID的映射
+func (s *Scheduler) LoadPathfinder(protocol models.LockProtocol) (map[string]int, error) {
+	if !ValidLockProtocol(protocol) {
+		return nil, fmt.Errorf("不支持的锁协议 %s", protocol)
+	}
+	cfg := s.Config()
+	cfg.ProcessorCount = 1
+	cfg.Processors = nil
+	cfg.Policy = models.PolicyPriority
+	cfg.AgingInterval = 0
+	cfg.LockProtocol = protocol
+	if err := s.Configure(cfg, true); err != nil {
+		return nil, err
+	}
+	return s.SubmitWorkload(PathfinderWorkload())
+}
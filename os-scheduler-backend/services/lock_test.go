@@ -0,0 +1,182 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"strings"
+	"testing"
+)
+
+// eventTypes 返回时间线事件中各类型出现的次数
+func eventTypes(s *Scheduler) map[string]int {
+	types := make(map[string]int)
+	for _, e := range s.Events {
+		types[e.Type]++
+	}
+	return types
+}
+
+func TestPathfinderProtocols(t *testing.T) {
+	cases := []struct {
+		protocol models.LockProtocol
+		lockWait int  // bus_dist 因等待锁阻塞的时钟周期数
+		beatsCom bool // bus_dist 先于 comms 完成
+		boosts   int
+	}{
+		{models.LockNone, 8, false, 0},
+		{models.LockInheritance, 2, true, 1},
+		{models.LockCeiling, 0, true, 1},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.protocol), func(t *testing.T) {
+			s := newTestSystem(t, nil)
+			mapping, err := s.LoadPathfinder(tc.protocol)
+			if err != nil {
+				t.Fatalf("LoadPathfinder: %v", err)
+			}
+			runUntilDone(t, s, 100)
+
+			busDist := s.findProcess(mapping["bus_dist"])
+			comms := s.findProcess(mapping["comms"])
+			if busDist.LockWait != tc.lockWait {
+				t.Errorf("bus_dist lockWait = %d, want %d", busDist.LockWait, tc.lockWait)
+			}
+			if got := busDist.FinishTime < comms.FinishTime; got != tc.beatsCom {
+				t.Errorf("bus_dist finished at %d, comms at %d", busDist.FinishTime, comms.FinishTime)
+			}
+			types := eventTypes(s)
+			if types[models.TimelinePriorityBoost] != tc.boosts || types[models.TimelinePriorityRestore] != tc.boosts {
+				t.Errorf("boost/restore events = %d/%d, want %d each",
+					types[models.TimelinePriorityBoost], types[models.TimelinePriorityRestore], tc.boosts)
+			}
+			for _, p := range s.allProcesses() {
+				if p.Boost != 0 || p.HeldLock != "" {
+					t.Errorf("process %s finished with boost %d holding %q", p.Name, p.Boost, p.HeldLock)
+				}
+			}
+		})
+	}
+}
+
+func TestInheritanceBoostsHolderWhileWaited(t *testing.T) {
+	s := newTestSystem(t, nil)
+	mapping, err := s.LoadPathfinder(models.LockInheritance)
+	if err != nil {
+		t.Fatalf("LoadPathfinder: %v", err)
+	}
+	meteo := s.findProcess(mapping["meteo"])
+	busDist := s.findProcess(mapping["bus_dist"])
+
+	for busDist.BlockedOn == "" {
+		s.Schedule()
+	}
+	s.Schedule()
+	if meteo.HeldLock != "bus" || meteo.Boost <= 0 || meteo.Priority != busDist.Priority {
+		t.Fatalf("holder not boosted: held=%q priority=%d boost=%d, waiter priority %d",
+			meteo.HeldLock, meteo.Priority, meteo.Boost, busDist.Priority)
+	}
+	for meteo.HeldLock != "" {
+		s.Schedule()
+	}
+	if meteo.Boost != 0 {
+		t.Errorf("boost = %d after release, want 0", meteo.Boost)
+	}
+	if busDist.HeldLock != "bus" || busDist.State == models.Waiting {
+		t.Errorf("waiter did not receive the lock: held=%q state=%s", busDist.HeldLock, busDist.State)
+	}
+}
+
+func TestLockHandoffPrefersHighestPriority(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.ProcessorCount = 3 })
+	section := []models.CriticalSection{{Lock: "l", Start: 0, Length: 2}}
+	holder := addTestProcess(t, s, &models.PCB{Name: "holder", RequiredTime: 2, Priority: 50, CriticalSections: section})
+	s.Schedule()
+	low := addTestProcess(t, s, &models.PCB{Name: "low", RequiredTime: 2, Priority: 1, CriticalSections: section})
+	high := addTestProcess(t, s, &models.PCB{Name: "high", RequiredTime: 2, Priority: 9, CriticalSections: section})
+	for s.findProcess(holder).State != models.Finished {
+		s.Schedule()
+	}
+	if got := s.findProcess(high).HeldLock; got != "l" {
+		t.Errorf("high priority waiter holds %q, want the lock", got)
+	}
+	if got := s.findProcess(low).BlockedOn; got != "l" {
+		t.Errorf("low priority waiter blocked on %q, want l", got)
+	}
+}
+
+func TestValidateSections(t *testing.T) {
+	cases := []struct {
+		name     string
+		sections []models.CriticalSection
+		want     string
+	}{
+		{"valid", []models.CriticalSection{{Lock: "b", Start: 3, Length: 1}, {Lock: "a", Start: 0, Length: 2}}, ""},
+		{"missing lock", []models.CriticalSection{{Start: 0, Length: 1}}, "缺少锁名"},
+		{"zero length", []models.CriticalSection{{Lock: "a", Start: 0, Length: 0}}, "长度必须大于0"},
+		{"overlap", []models.CriticalSection{{Lock: "a", Start: 0, Length: 3}, {Lock: "b", Start: 2, Length: 1}}, "重叠"},
+		{"beyond burst", []models.CriticalSection{{Lock: "a", Start: 3, Length: 3}}, "超出运行时间"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSections("p", tc.sections, 5)
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("validateSections: %v", err)
+				}
+				if tc.sections[0].Lock != "a" {
+					t.Errorf("sections not sorted by start: %+v", tc.sections)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("validateSections error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestFastProcessorsStopAtSectionBoundaries(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) {
+		cfg.ProcessorCount = 1
+		cfg.Processors = []models.ProcessorSpec{{Speed: 3}}
+	})
+	sections := []models.CriticalSection{{Lock: "l", Start: 1, Length: 1}, {Lock: "m", Start: 3, Length: 3}}
+	p := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 9, CriticalSections: sections}))
+
+	// 每个时钟周期最多推进3个单位，但在临界区的开始处和结束处停下
+	s.Schedule()
+	for _, want := range []int{1, 2, 3, 6, 9} {
+		s.Schedule()
+		if got := progress(p); got != want {
+			t.Fatalf("tick %d: progress = %d, want %d", s.Clock, got, want)
+		}
+	}
+}
+
+func TestFastProcessorsStillExcludeEachOther(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) {
+		cfg.ProcessorCount = 2
+		cfg.Processors = []models.ProcessorSpec{{Speed: 3}, {Speed: 3}}
+	})
+	sections := []models.CriticalSection{{Lock: "l", Start: 1, Length: 4}}
+	a := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 8, CriticalSections: sections}))
+	b := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 8, CriticalSections: sections}))
+
+	held := make(map[int]bool)
+	for tick := 0; tick < 50 && s.unfinished() > 0; tick++ {
+		s.Schedule()
+		if a.HeldLock != "" && b.HeldLock != "" {
+			t.Fatalf("tick %d: both processes hold the lock", s.Clock)
+		}
+		held[a.PID] = held[a.PID] || a.HeldLock == "l"
+		held[b.PID] = held[b.PID] || b.HeldLock == "l"
+	}
+	if s.unfinished() > 0 {
+		t.Fatal("processes did not finish")
+	}
+	if !held[a.PID] || !held[b.PID] {
+		t.Errorf("a process ran its section without the lock: held %v", held)
+	}
+	if a.LockWait+b.LockWait == 0 {
+		t.Error("neither process waited for the lock")
+	}
+}
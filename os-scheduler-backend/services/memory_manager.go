@@ -3,50 +3,149 @@ package services
 import (
     "os-scheduler-backend/models"
     "errors"
+    "fmt"
 )
 
 type MemoryManager struct {
-    Memory *models.MemoryManager
+    Memory   *models.MemoryManager
+    eventLog *EventLog // 记录外部发起的内存操作，为空时不记录
 }
 
 func NewMemoryManager(totalSize, osSize int) *MemoryManager {
     return &MemoryManager{
-        Memory: &models.MemoryManager{
-            TotalSize: totalSize,
-            OSSize:    osSize,
-            Blocks: []models.MemoryBlock{
-                {
-                    Start:  osSize,
-                    Length: totalSize - osSize,
-                    IsUsed: false,
-                },
+        Memory: newMemory(totalSize, osSize),
+    }
+}
+
+func newMemory(totalSize, osSize int) *models.MemoryManager {
+    return &models.MemoryManager{
+        TotalSize:    totalSize,
+        OSSize:       osSize,
+        Algorithm:    models.FirstFit,
+        NextFitStart: osSize,
+        Blocks: []models.MemoryBlock{
+            {
+                Start:  osSize,
+                Length: totalSize - osSize,
+                IsUsed: false,
             },
         },
     }
 }
 
+// Reset 释放所有内存分配，恢复到初始状态，分配算法保持不变
+func (mm *MemoryManager) Reset() {
+    algorithm := mm.Memory.Algorithm
+    mm.Memory = newMemory(mm.Memory.TotalSize, mm.Memory.OSSize)
+    mm.Memory.Algorithm = algorithm
+}
+
+// SetAlgorithm 设置内存分配算法
+func (mm *MemoryManager) SetAlgorithm(algorithm models.AllocationAlgorithm) error {
+    if !ValidAllocator(algorithm) {
+        return fmt.Errorf("不支持的内存分配算法 %s", algorithm)
+    }
+    mm.Memory.Algorithm = algorithm
+    mm.eventLog.Record(OpSetAllocator, allocatorArgs{Allocator: algorithm})
+    return nil
+}
+
+// ValidAllocator 判断是否为支持的内存分配算法
+func ValidAllocator(algorithm models.AllocationAlgorithm) bool {
+    switch algorithm {
+    case models.FirstFit, models.NextFit, models.BestFit, models.WorstFit:
+        return true
+    }
+    return false
+}
+
+// Allocate 分配指定大小的内存，返回起始地址
 func (mm *MemoryManager) Allocate(size int) (int, error) {
-    for i, block := range mm.Memory.Blocks {
-        if !block.IsUsed && block.Length >= size {
-            // 首次适应算法
-            mm.Memory.Blocks[i].IsUsed = true
-            if block.Length > size {
-                // 分割块
-                newBlock := models.MemoryBlock{
-                    Start:  block.Start + size,
-                    Length: block.Length - size,
-                    IsUsed: false,
-                }
-                mm.Memory.Blocks[i].Length = size
-                mm.Memory.Blocks = append(mm.Memory.Blocks[:i+1], append([]models.MemoryBlock{newBlock}, mm.Memory.Blocks[i+1:]...)...)
+    start, err := mm.allocate(size)
+    if err == nil {
+        mm.eventLog.Record(OpAllocate, allocateArgs{Size: size, Start: start})
+    }
+    return start, err
+}
+
+func (mm *MemoryManager) allocate(size int) (int, error) {
+    // 不占用内存的进程不分配内存块，起始地址为-1，释放时不做任何操作
+    if size <= 0 {
+        return -1, nil
+    }
+    i := mm.findBlock(size)
+    if i < 0 {
+        return -1, errors.New("no suitable memory block found")
+    }
+
+    block := mm.Memory.Blocks[i]
+    mm.Memory.Blocks[i].IsUsed = true
+    if block.Length > size {
+        // 分割块
+        newBlock := models.MemoryBlock{
+            Start:  block.Start + size,
+            Length: block.Length - size,
+            IsUsed: false,
+        }
+        mm.Memory.Blocks[i].Length = size
+        mm.Memory.Blocks = append(mm.Memory.Blocks[:i+1], append([]models.MemoryBlock{newBlock}, mm.Memory.Blocks[i+1:]...)...)
+    }
+    mm.Memory.NextFitStart = block.Start + size
+    return block.Start, nil
+}
+
+// findBlock 按分配算法选择空闲块，返回其下标，没有合适的块时返回-1
+func (mm *MemoryManager) findBlock(size int) int {
+    blocks := mm.Memory.Blocks
+    fits := func(i int) bool {
+        return !blocks[i].IsUsed && blocks[i].Length >= size
+    }
+
+    switch mm.Memory.Algorithm {
+    case models.NextFit:
+        // 从上次分配结束的位置开始查找，到末尾后回到开头
+        first := 0
+        for first < len(blocks) && blocks[first].Start+blocks[first].Length <= mm.Memory.NextFitStart {
+            first++
+        }
+        for k := 0; k < len(blocks); k++ {
+            i := (first + k) % len(blocks)
+            if fits(i) {
+                return i
+            }
+        }
+        return -1
+    case models.BestFit, models.WorstFit:
+        chosen := -1
+        for i := range blocks {
+            if !fits(i) {
+                continue
+            }
+            if chosen < 0 ||
+                (mm.Memory.Algorithm == models.BestFit && blocks[i].Length < blocks[chosen].Length) ||
+                (mm.Memory.Algorithm == models.WorstFit && blocks[i].Length > blocks[chosen].Length) {
+                chosen = i
             }
-            return block.Start, nil
         }
+        return chosen
+    default:
+        // 首次适应算法
+        for i := range blocks {
+            if fits(i) {
+                return i
+            }
+        }
+        return -1
     }
-    return -1, errors.New("no suitable memory block found")
 }
 
+// Free 释放从start开始的内存块
 func (mm *MemoryManager) Free(start int) {
+    mm.free(start)
+    mm.eventLog.Record(OpFree, freeArgs{Start: start})
+}
+
+func (mm *MemoryManager) free(start int) {
     for i, block := range mm.Memory.Blocks {
         if block.Start == start {
             mm.Memory.Blocks[i].IsUsed = false
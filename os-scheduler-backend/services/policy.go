@@ -0,0 +1,120 @@
+package services
+
+import (
+	"fmt"
+	"os-scheduler-backend/models"
+	"sort"
+)
+
+// DefaultTimeQuantum 时间片轮转的默认时间片长度
+const DefaultTimeQuantum = 2
+
+// ValidPolicy 判断是否为支持的调度策略
+func ValidPolicy(policy models.SchedulingPolicy) bool {
+	switch policy {
+	case models.PolicyPriority, models.PolicyFCFS, models.PolicySJF, models.PolicyRR, models.PolicyRM, models.PolicyEDF,
+		models.PolicyLottery, models.PolicyStride, models.PolicyCFS, models.PolicyFairShare:
+		return true
+	}
+	return false
+}
+
+// SetPolicy 设置调度策略，新策略从下一次调度开始生效
+func (s *Scheduler) SetPolicy(policy models.SchedulingPolicy, timeQuantum int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !ValidPolicy(policy) {
+		return fmt.Errorf("不支持的调度策略 %s", policy)
+	}
+	if timeQuantum <= 0 {
+		return fmt.Errorf("时间片长度必须大于0")
+	}
+	s.Policy = policy
+	s.TimeQuantum = timeQuantum
+	s.sortReadyQueue()
+	s.eventLog.Record(OpSetPolicy, policyArgs{Policy: policy, TimeQuantum: timeQuantum})
+	return nil
+}
+
+// keepsProcessor 判断运行中的进程在本次调度后是否继续占用处理机
+func (s *Scheduler) keepsProcessor(p *models.PCB) bool {
+	// 获得处理机后只做了上下文切换、还没有运行过的进程不让出处理机，否则切换开销白白浪费
+	if p.QuantumUsed == 0 {
+		return true
+	}
+	switch s.Policy {
+	case models.PolicyFCFS:
+		return true
+	case models.PolicyRR:
+		return p.QuantumUsed < s.TimeQuantum
+	case models.PolicyCFS:
+		return p.QuantumUsed < s.cfsSlice(p)
+	default:
+		// 动态优先数、最短剩余时间优先、实时调度和比例份额调度在每个时钟周期都重新选择
+		return false
+	}
+}
+
+// sortReadyQueue 按调度策略对就绪队列排序，队首的进程最先获得处理机
+func (s *Scheduler) sortReadyQueue() {
+	ready := s.Queue.Ready
+	switch s.Policy {
+	case models.PolicyFCFS:
+		sort.SliceStable(ready, func(i, j int) bool {
+			if ready[i].ArrivalTime != ready[j].ArrivalTime {
+				return ready[i].ArrivalTime < ready[j].ArrivalTime
+			}
+			return ready[i].PID < ready[j].PID
+		})
+	case models.PolicySJF:
+		sort.SliceStable(ready, func(i, j int) bool {
+			return ready[i].RequiredTime < ready[j].RequiredTime
+		})
+	case models.PolicyRR:
+		// 时间片轮转按进入就绪队列的先后顺序调度
+	case models.PolicyLottery:
+		// 彩票调度在分配处理机前抽签决定顺序
+	case models.PolicyStride:
+		s.liftPass()
+		sort.SliceStable(ready, func(i, j int) bool {
+			if ready[i].Pass != ready[j].Pass {
+				return ready[i].Pass < ready[j].Pass
+			}
+			return ready[i].PID < ready[j].PID
+		})
+	case models.PolicyFairShare:
+		strides := s.fairStrides()
+		s.liftFairPass(strides)
+		sort.SliceStable(ready, func(i, j int) bool {
+			if ready[i].FairPass != ready[j].FairPass {
+				return ready[i].FairPass < ready[j].FairPass
+			}
+			return ready[i].PID < ready[j].PID
+		})
+	case models.PolicyCFS:
+		// 共用就绪队列时由按虚拟运行时间排序的堆选择进程，不必每次排序；
+		// 独立就绪队列按就绪队列中的顺序取进程，仍需排序
+		if s.PerProcessorQueues {
+			s.placeVRuntime()
+			sort.SliceStable(ready, func(i, j int) bool {
+				if ready[i].VRuntime != ready[j].VRuntime {
+					return ready[i].VRuntime < ready[j].VRuntime
+				}
+				return ready[i].PID < ready[j].PID
+			})
+		}
+	case models.PolicyRM, models.PolicyEDF:
+		sort.SliceStable(ready, func(i, j int) bool {
+			return s.realTimeLess(ready[i], ready[j])
+		})
+	default:
+		sort.SliceStable(ready, func(i, j int) bool {
+			if ready[i].Priority != ready[j].Priority {
+				return ready[i].Priority > ready[j].Priority
+			}
+			// 优先数相同时持有锁的进程优先，尽快释放锁
+			return ready[i].HeldLock != "" && ready[j].HeldLock == ""
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"fmt"
+	"os-scheduler-backend/models"
+)
+
+// 前驱关系校验失败的原因
+const (
+	ReasonUnknownPredecessor = "unknown_predecessor" // 前驱进程不存在
+	ReasonSelfReference      = "self_reference"      // 进程以自身为前驱
+	ReasonCycle              = "cycle"               // 前驱图中存在环
+	ReasonDuplicateID        = "duplicate_id"        // 批内ID为空或重复
+)
+
+// PrecedenceError 描述前驱关系校验失败的具体原因
+type PrecedenceError struct {
+	Reason      string `json:"reason"`                // 失败原因
+	PID         int    `json:"pid"`                   // 出错的进程PID
+	Predecessor int    `json:"predecessor,omitempty"` // 出错的前驱PID
+	Cycle       []int  `json:"cycle,omitempty"`       // 环上的进程PID，首尾相同
+
+	// 批量提交时对应的批内ID
+	LocalID          string   `json:"localId,omitempty"`
+	LocalPredecessor string   `json:"localPredecessor,omitempty"`
+	LocalCycle       []string `json:"localCycle,omitempty"`
+}
+
+func (e *PrecedenceError) Error() string {
+	process := fmt.Sprintf("%d", e.PID)
+	if e.LocalID != "" {
+		process = e.LocalID
+	}
+	predecessor := fmt.Sprintf("%d", e.Predecessor)
+	if e.LocalPredecessor != "" {
+		predecessor = e.LocalPredecessor
+	}
+
+	switch e.Reason {
+	case ReasonUnknownPredecessor:
+		return fmt.Sprintf("进程 %s 的前驱进程 %s 不存在", process, predecessor)
+	case ReasonSelfReference:
+		return fmt.Sprintf("进程 %s 不能以自身为前驱", process)
+	case ReasonCycle:
+		if len(e.LocalCycle) > 0 {
+			return fmt.Sprintf("进程 %s 的前驱关系构成环: %v", process, e.LocalCycle)
+		}
+		return fmt.Sprintf("进程 %s 的前驱关系构成环: %v", process, e.Cycle)
+	case ReasonDuplicateID:
+		return fmt.Sprintf("批内ID \"%s\" 为空或重复", process)
+	default:
+		return fmt.Sprintf("进程 %s 的前驱关系无效", process)
+	}
+}
+
+// uniquePIDs 去除PID列表中的重复项，保持原有顺序
+func uniquePIDs(pids []int) []int {
+	seen := make(map[int]bool, len(pids))
+	result := make([]int, 0, len(pids))
+	for _, pid := range pids {
+		if !seen[pid] {
+			seen[pid] = true
+			result = append(result, pid)
+		}
+	}
+	return result
+}
+
+// validatePrecedence 校验新进程的前驱关系，新进程须已分配PID
+// 前驱必须是已存在（包括已完成）的进程或同批提交的进程，且整个前驱图不能有环
+func (s *Scheduler) validatePrecedence(newProcesses []*models.PCB) error {
+	graph := make(map[int][]int)
+	for _, p := range s.allProcesses() {
+		graph[p.PID] = p.Predecessors
+	}
+	for _, p := range newProcesses {
+		graph[p.PID] = p.Predecessors
+	}
+
+	for _, p := range newProcesses {
+		for _, predPID := range p.Predecessors {
+			if predPID == p.PID {
+				return &PrecedenceError{Reason: ReasonSelfReference, PID: p.PID, Predecessor: predPID}
+			}
+			if _, ok := graph[predPID]; !ok {
+				return &PrecedenceError{Reason: ReasonUnknownPredecessor, PID: p.PID, Predecessor: predPID}
+			}
+		}
+	}
+
+	// 已有进程的前驱图无环，环一定经过新进程。所有搜索共用访问标记，
+	// 已搜索过的进程不会再走到环，整个校验只遍历前驱图一次
+	color := make(map[int]int, len(graph))
+	for _, p := range newProcesses {
+		if cycle := findCycle(graph, color, p.PID); cycle != nil {
+			return &PrecedenceError{Reason: ReasonCycle, PID: p.PID, Cycle: cycle}
+		}
+	}
+	return nil
+}
+
+// findCycle 从start出发沿前驱边深度优先搜索，返回找到的环，没有环时返回nil。
+// color记录各进程的访问标记，跳过之前的搜索已确认不在环上的进程
+func findCycle(graph map[int][]int, color map[int]int, start int) []int {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	if color[start] == visited {
+		return nil
+	}
+	path := make([]int, 0)
+
+	var visit func(pid int) []int
+	visit = func(pid int) []int {
+		color[pid] = visiting
+		path = append(path, pid)
+		for _, pred := range graph[pid] {
+			switch color[pred] {
+			case visiting:
+				// 从路径中截取环
+				for i, v := range path {
+					if v == pred {
+						cycle := append([]int{}, path[i:]...)
+						return append(cycle, pred)
+					}
+				}
+			case unvisited:
+				if cycle := visit(pred); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		color[pid] = visited
+		return nil
+	}
+	return visit(start)
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os-scheduler-backend/models"
+	"reflect"
+	"testing"
+)
+
+// chainBatch 返回n个进程组成的链，每个进程以前一个进程为前驱
+func chainBatch(n int) []models.BatchProcess {
+	batch := make([]models.BatchProcess, n)
+	for i := range batch {
+		batch[i] = models.BatchProcess{ID: fmt.Sprintf("p%d", i), RequiredTime: 1}
+		if i > 0 {
+			batch[i].Predecessors = []string{batch[i-1].ID}
+		}
+	}
+	return batch
+}
+
+func TestValidatePrecedenceErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		batch  []models.BatchProcess
+		reason string
+		local  string
+		cycle  []string
+	}{
+		{
+			name:   "unknown predecessor",
+			batch:  []models.BatchProcess{{ID: "a", RequiredTime: 1, Predecessors: []string{"x"}}},
+			reason: ReasonUnknownPredecessor,
+			local:  "a",
+		},
+		{
+			name:   "self reference",
+			batch:  []models.BatchProcess{{ID: "a", RequiredTime: 1, Predecessors: []string{"a"}}},
+			reason: ReasonSelfReference,
+			local:  "a",
+		},
+		{
+			name: "cycle",
+			batch: []models.BatchProcess{
+				{ID: "a", RequiredTime: 1},
+				{ID: "b", RequiredTime: 1, Predecessors: []string{"a", "d"}},
+				{ID: "c", RequiredTime: 1, Predecessors: []string{"b"}},
+				{ID: "d", RequiredTime: 1, Predecessors: []string{"c"}},
+			},
+			reason: ReasonCycle,
+			local:  "b",
+			cycle:  []string{"b", "d", "c", "b"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestSystem(t, nil)
+			_, _, err := s.AddProcessBatch(tc.batch)
+			var precedenceErr *PrecedenceError
+			if !errors.As(err, &precedenceErr) {
+				t.Fatalf("AddProcessBatch error = %v, want a *PrecedenceError", err)
+			}
+			if precedenceErr.Reason != tc.reason || precedenceErr.LocalID != tc.local {
+				t.Errorf("error = %+v, want reason %s at %s", precedenceErr, tc.reason, tc.local)
+			}
+			if tc.cycle != nil && !reflect.DeepEqual(precedenceErr.LocalCycle, tc.cycle) {
+				t.Errorf("cycle = %v, want %v", precedenceErr.LocalCycle, tc.cycle)
+			}
+		})
+	}
+}
+
+func TestValidatePrecedenceLongChain(t *testing.T) {
+	s := newTestSystem(t, nil)
+	batch := chainBatch(MaxGeneratedProcesses)
+	mapping, _, err := s.AddProcessBatch(batch)
+	if err != nil {
+		t.Fatalf("AddProcessBatch: %v", err)
+	}
+
+	// 以链尾和链首为前驱的新进程，校验时整条链只遍历一次
+	first := mapping[batch[0].ID]
+	last := mapping[batch[len(batch)-1].ID]
+	addTestProcess(t, s, &models.PCB{Name: "tail", RequiredTime: 1, Predecessors: []int{last, first}})
+}
+
+func TestAddProcessPrecedenceErrors(t *testing.T) {
+	cases := []struct {
+		name         string
+		predecessors []int
+		reason       string
+	}{
+		{"unknown predecessor", []int{7}, ReasonUnknownPredecessor},
+		{"self reference", []int{2}, ReasonSelfReference},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestSystem(t, nil)
+			addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 1})
+			err := s.AddProcess(&models.PCB{Name: "b", RequiredTime: 1, Predecessors: tc.predecessors})
+			var precedenceErr *PrecedenceError
+			if !errors.As(err, &precedenceErr) {
+				t.Fatalf("AddProcess error = %v, want a *PrecedenceError", err)
+			}
+			if precedenceErr.Reason != tc.reason || precedenceErr.PID != 2 {
+				t.Errorf("error = %+v, want reason %s at 2", precedenceErr, tc.reason)
+			}
+			if len(s.allProcesses()) != 1 {
+				t.Errorf("rejected process was added")
+			}
+		})
+	}
+}
+
+func TestValidatePrecedenceFindsCycle(t *testing.T) {
+	s := newTestSystem(t, nil)
+	a := addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 1})
+	processes := []*models.PCB{
+		{PID: 2, Predecessors: []int{a, 4}},
+		{PID: 3, Predecessors: []int{2}},
+		{PID: 4, Predecessors: []int{3}},
+	}
+	err := s.validatePrecedence(processes)
+	var precedenceErr *PrecedenceError
+	if !errors.As(err, &precedenceErr) || precedenceErr.Reason != ReasonCycle {
+		t.Fatalf("validatePrecedence error = %v, want a cycle", err)
+	}
+	if want := []int{2, 4, 3, 2}; !reflect.DeepEqual(precedenceErr.Cycle, want) {
+		t.Errorf("cycle = %v, want %v", precedenceErr.Cycle, want)
+	}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os-scheduler-backend/models"
+)
+
+// SetProcessorCount 在运行中增减处理机。被移除的处理机上运行的进程被抢占回就绪队列，
+// 新增的处理机速度为1，从下一次调度开始接收进程，变化记录在时间线事件中。
+// 有未完成的进程只允许在被移除的处理机上运行时拒绝减少处理机
+func (s *Scheduler) SetProcessorCount(count int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.setProcessorCount(count); err != nil {
+		return err
+	}
+	s.eventLog.Record(OpSetProcessors, processorsArgs{Count: count})
+	return nil
+}
+
+func (s *Scheduler) setProcessorCount(count int) error {
+	if count <= 0 {
+		return errors.New("处理机数量必须大于0")
+	}
+	if count == s.ProcessorCount {
+		return nil
+	}
+	if err := s.checkGangs(nil, count, s.MaxProcesses); err != nil {
+		return err
+	}
+	for _, p := range s.allProcesses() {
+		if p.State != models.Finished && !allowedWithin(p.AffinityMask, count) {
+			return fmt.Errorf("进程 %d 的亲和性只允许在将被移除的处理机上运行", p.PID)
+		}
+	}
+
+	preempted := make([]int, 0)
+	for _, p := range append([]*models.PCB(nil), s.Queue.Running...) {
+		if p.ProcessorID < count {
+			continue
+		}
+		s.preempt(p, fmt.Sprintf("处理机 %d 被移除", p.ProcessorID))
+		preempted = append(preempted, p.PID)
+	}
+	if len(preempted) > 0 {
+		s.sortReadyQueue()
+	}
+
+	message := fmt.Sprintf("处理机数量由 %d 变为 %d", s.ProcessorCount, count)
+	if len(preempted) > 0 {
+		message += fmt.Sprintf("，抢占进程 %v", preempted)
+	}
+	s.Events = append(s.Events, models.TimelineEvent{
+		Tick:    s.Clock,
+		Type:    models.TimelineProcessors,
+		Message: message,
+		PIDs:    preempted,
+	})
+	s.ProcessorCount = count
+	s.Processors = normalizeProcessors(s.Processors, count)
+	return nil
+}
+
+// normalizeProcessors 返回恰好count个处理机的规格，缺少的处理机速度为1，速度为0时视为1
+func normalizeProcessors(specs []models.ProcessorSpec, count int) []models.ProcessorSpec {
+	normalized := make([]models.ProcessorSpec, count)
+	for i := range normalized {
+		normalized[i] = models.ProcessorSpec{Speed: 1}
+		if i < len(specs) {
+			normalized[i] = specs[i]
+			if normalized[i].Speed == 0 {
+				normalized[i].Speed = 1
+			}
+		}
+	}
+	return normalized
+}
+
+// speed 返回处理机每个时钟周期完成的工作量，单位为千分之一
+func (s *Scheduler) speed(cpu int) int {
+	if cpu < 0 || cpu >= len(s.Processors) {
+		return 1000
+	}
+	return int(math.Round(s.Processors[cpu].Speed * 1000))
+}
+
+// faster 判断处理机a是否比处理机b快
+func (s *Scheduler) faster(a, b int) bool {
+	return s.speed(a) > s.speed(b)
+}
+
+// 处理机速度的范围，速度为0表示1
+const (
+	MinProcessorSpeed = 0.001
+	MaxProcessorSpeed = 1000.0
+)
+
+// validSpeed 判断处理机速度是否在允许的范围内
+func validSpeed(speed float64) bool {
+	return speed >= MinProcessorSpeed && speed <= MaxProcessorSpeed
+}
+
+// validProcessors 判断处理机规格是否有效
+func validProcessors(specs []models.ProcessorSpec) bool {
+	for _, spec := range specs {
+		if spec.Speed != 0 && !validSpeed(spec.Speed) {
+			return false
+		}
+	}
+	return true
+}
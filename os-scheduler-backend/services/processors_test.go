@@ -0,0 +1,97 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"strings"
+	"testing"
+)
+
+// runUntilDone 调度直到所有进程完成，超过limit个时钟周期时测试失败
+func runUntilDone(t *testing.T, s *Scheduler, limit int) {
+	t.Helper()
+	for s.unfinished() > 0 {
+		if s.Clock > limit {
+			t.Fatalf("processes still unfinished at tick %d: %d", s.Clock, s.unfinished())
+		}
+		s.Schedule()
+	}
+}
+
+func TestRemovingProcessorsPreemptsTheirProcesses(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.ProcessorCount = 3 })
+	pids := make([]int, 0, 3)
+	for _, name := range []string{"a", "b", "c"} {
+		pids = append(pids, addTestProcess(t, s, &models.PCB{Name: name, RequiredTime: 5}))
+	}
+	s.Schedule()
+	s.Schedule()
+
+	if err := s.SetProcessorCount(1); err != nil {
+		t.Fatalf("SetProcessorCount: %v", err)
+	}
+	if len(s.Queue.Running) != 1 || s.Queue.Running[0].ProcessorID != 0 {
+		t.Fatalf("running = %d processes, want one on processor 0", len(s.Queue.Running))
+	}
+	event := s.Events[len(s.Events)-1]
+	if event.Type != models.TimelineProcessors || event.Tick != s.Clock || len(event.PIDs) != 2 {
+		t.Errorf("event = %+v, want a processors event preempting 2 processes", event)
+	}
+	for _, pid := range event.PIDs {
+		if p := s.findProcess(pid); p.State != models.Ready || p.ProcessorID != -1 {
+			t.Errorf("preempted process %d: state=%s cpu=%d", pid, p.State, p.ProcessorID)
+		}
+	}
+
+	// 之后的时间线只有一个处理机，统计按各处理机存在的时钟周期计算利用率
+	runUntilDone(t, s, 30)
+	if got := len(s.Timeline[len(s.Timeline)-1].Processors); got != 1 {
+		t.Errorf("timeline slot has %d processors, want 1", got)
+	}
+	stats := s.Stats()
+	if len(stats.PerProcessor) != 1 || stats.PerProcessor[0].Ticks != s.Clock {
+		t.Errorf("per processor stats = %+v", stats.PerProcessor)
+	}
+	if stats.Finished != len(pids) {
+		t.Errorf("%d processes finished, want %d", stats.Finished, len(pids))
+	}
+}
+
+func TestAddedProcessorsTakeWorkNextTick(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.ProcessorCount = 1 })
+	a := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 4}))
+	b := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 4}))
+	s.Schedule()
+	if err := s.SetProcessorCount(2); err != nil {
+		t.Fatalf("SetProcessorCount: %v", err)
+	}
+	if a.State != models.Running || b.State != models.Ready {
+		t.Fatalf("adding a processor changed the running set: a=%s b=%s", a.State, b.State)
+	}
+	s.Schedule()
+	if a.State != models.Running || b.State != models.Running || a.ProcessorID == b.ProcessorID {
+		t.Errorf("after the next tick: a=%s on %d, b=%s on %d, want both running", a.State, a.ProcessorID, b.State, b.ProcessorID)
+	}
+	if got := s.Processors[1].Speed; got != 1 {
+		t.Errorf("new processor speed = %v, want 1", got)
+	}
+}
+
+func TestSetProcessorCountRejections(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.ProcessorCount = 3 })
+	if err := s.SetProcessorCount(0); err == nil {
+		t.Error("SetProcessorCount accepted zero processors")
+	}
+	if err := s.SetProcessorCount(3); err != nil || len(s.Events) != 0 {
+		t.Errorf("unchanged count: err=%v events=%d", err, len(s.Events))
+	}
+
+	// 只允许在将被移除的处理机上运行的进程阻止减少处理机
+	addTestProcess(t, s, &models.PCB{Name: "pinned", RequiredTime: 3, AffinityMask: 1 << 2})
+	err := s.SetProcessorCount(2)
+	if err == nil || !strings.Contains(err.Error(), "亲和性") {
+		t.Fatalf("SetProcessorCount error = %v, want an affinity error", err)
+	}
+	if s.ProcessorCount != 3 || len(s.Processors) != 3 {
+		t.Errorf("rejected SetProcessorCount changed the processors: %d", s.ProcessorCount)
+	}
+}
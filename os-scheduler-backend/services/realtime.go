@@ -0,0 +1,304 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os-scheduler-backend/models"
+	"sort"
+)
+
+// AddTask 添加实时任务，分配任务ID，成功后把补全的任务写回task。周期任务从当前时钟加上相位开始
+// 每个周期释放一个作业，偶发任务只在调用 ReleaseJob 时释放作业
+func (s *Scheduler) AddTask(task *models.RealTimeTask) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// 在副本上补全和校验，校验失败时不修改调用者的任务
+	submitted := *task
+	t := submitted
+	if t.Deadline == 0 {
+		t.Deadline = t.Period
+	}
+	userMemory := s.memoryManager.Memory.TotalSize - s.memoryManager.Memory.OSSize
+	switch {
+	case t.Period <= 0:
+		return errors.New("周期必须大于0")
+	case t.WCET <= 0:
+		return errors.New("最坏执行时间必须大于0")
+	case t.Deadline < 0 || t.Deadline > t.Period:
+		return errors.New("相对截止时间必须在0到周期之间")
+	case t.WCET > t.Deadline:
+		return errors.New("最坏执行时间不能超过相对截止时间")
+	case t.Phase < 0:
+		return errors.New("相位不能为负数")
+	case t.MemorySize < 0 || t.MemorySize > userMemory:
+		return fmt.Errorf("内存大小必须在0到%d之间", userMemory)
+	}
+
+	t.ID = s.nextTaskID
+	t.NextRelease = s.Clock + t.Phase
+	t.Jobs = 0
+	if t.Name == "" {
+		t.Name = fmt.Sprintf("T%d", t.ID)
+	}
+	s.nextTaskID++
+	s.Tasks = append(s.Tasks, &t)
+
+	// 相位为0的周期任务立即释放第一个作业
+	s.releaseJobs()
+	s.admitArrivals()
+	*task = t
+
+	s.eventLog.Record(OpAddTask, addTaskArgs{Task: &submitted, ID: t.ID})
+	return nil
+}
+
+// RemoveTask 删除实时任务，不再释放新的作业，已释放的作业继续运行
+func (s *Scheduler) RemoveTask(id int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i, task := range s.Tasks {
+		if task.ID == id {
+			s.Tasks = append(s.Tasks[:i], s.Tasks[i+1:]...)
+			s.eventLog.Record(OpRemoveTask, taskArgs{ID: id})
+			return nil
+		}
+	}
+	return fmt.Errorf("找不到实时任务 %d", id)
+}
+
+// ReleaseJob 在当前时钟释放偶发任务的一个作业，距上次释放不足最小间隔时返回错误。返回作业的PID
+func (s *Scheduler) ReleaseJob(id int) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	task := s.findTask(id)
+	if task == nil {
+		return 0, fmt.Errorf("找不到实时任务 %d", id)
+	}
+	if !task.Sporadic {
+		return 0, fmt.Errorf("实时任务 %d 是周期任务，按周期自动释放作业", id)
+	}
+	if s.Clock < task.NextRelease {
+		return 0, fmt.Errorf("距上次释放不足最小间隔，最早在时刻 %d 释放", task.NextRelease)
+	}
+
+	job := s.releaseJob(task, s.Clock)
+	task.NextRelease = s.Clock + task.Period
+	s.admitArrivals()
+
+	s.eventLog.Record(OpReleaseJob, releaseJobArgs{TaskID: id, PID: job.PID})
+	return job.PID, nil
+}
+
+// TaskList 返回所有实时任务的拷贝
+func (s *Scheduler) TaskList() []*models.RealTimeTask {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return models.CloneTasks(s.Tasks)
+}
+
+func (s *Scheduler) findTask(id int) *models.RealTimeTask {
+	for _, task := range s.Tasks {
+		if task.ID == id {
+			return task
+		}
+	}
+	return nil
+}
+
+// releaseJobs 释放所有已到释放时刻的周期任务作业
+func (s *Scheduler) releaseJobs() {
+	for _, task := range s.Tasks {
+		for !task.Sporadic && task.NextRelease <= s.Clock {
+			s.releaseJob(task, task.NextRelease)
+			task.NextRelease += task.Period
+		}
+	}
+}
+
+// releaseJob 在时刻at释放任务的一个作业，作业进入新建队列，分配到内存后才进入系统
+func (s *Scheduler) releaseJob(task *models.RealTimeTask, at int) *models.PCB {
+	task.Jobs++
+	job := &models.PCB{
+		Name:              fmt.Sprintf("%s#%d", task.Name, task.Jobs),
+		PID:               s.nextPID,
+		RequiredTime:      task.WCET,
+		TotalRequiredTime: task.WCET,
+		MemorySize:        task.MemorySize,
+		ProcessorID:       -1,
+		Predecessors:      make([]int, 0),
+		Successors:        make([]int, 0),
+		ArrivalTime:       at,
+		StartTime:         -1,
+		LastProcessor:     -1,
+		RunQueue:          -1,
+		TaskID:            task.ID,
+		Period:            task.Period,
+		Deadline:          at + task.Deadline,
+	}
+	s.nextPID++
+	s.transition(job, models.New, at, "释放实时作业")
+	s.Queue.New = append(s.Queue.New, job)
+	return job
+}
+
+// checkDeadlines 标记到当前时钟仍未完成且已过截止时刻的作业，每个作业只记录一次。
+// 错过截止时刻的作业继续运行
+func (s *Scheduler) checkDeadlines() {
+	missed := make([]*models.PCB, 0)
+	for _, p := range s.allProcesses() {
+		if p.TaskID != 0 && p.State != models.Finished && !p.DeadlineMissed && p.Deadline <= s.Clock {
+			p.DeadlineMissed = true
+			missed = append(missed, p)
+		}
+	}
+	sort.Slice(missed, func(i, j int) bool {
+		return missed[i].PID < missed[j].PID
+	})
+	for _, p := range missed {
+		s.Events = append(s.Events, models.TimelineEvent{
+			Tick:    s.Clock,
+			Type:    models.TimelineDeadlineMiss,
+			Message: fmt.Sprintf("作业 %s 错过截止时刻 %d", p.Name, p.Deadline),
+			PIDs:    []int{p.PID},
+		})
+	}
+}
+
+// realTimeLess 按速率单调或最早截止时间优先比较两个进程，实时作业排在普通进程之前，
+// 普通进程之间按到达先后排序
+func (s *Scheduler) realTimeLess(a, b *models.PCB) bool {
+	if (a.TaskID != 0) != (b.TaskID != 0) {
+		return a.TaskID != 0
+	}
+	if a.TaskID != 0 {
+		if s.Policy == models.PolicyRM && a.Period != b.Period {
+			return a.Period < b.Period
+		}
+		if a.Deadline != b.Deadline {
+			return a.Deadline < b.Deadline
+		}
+	}
+	if a.ArrivalTime != b.ArrivalTime {
+		return a.ArrivalTime < b.ArrivalTime
+	}
+	return a.PID < b.PID
+}
+
+// taskStats 按实时任务统计作业的完成和错过截止时刻的情况
+func (s *Scheduler) taskStats() []models.TaskStats {
+	byID := make(map[int]*models.TaskStats)
+	ids := make([]int, 0)
+	for _, p := range s.allProcesses() {
+		if p.TaskID == 0 {
+			continue
+		}
+		ts, ok := byID[p.TaskID]
+		if !ok {
+			ts = &models.TaskStats{TaskID: p.TaskID}
+			if task := s.findTask(p.TaskID); task != nil {
+				ts.Name = task.Name
+			}
+			byID[p.TaskID] = ts
+			ids = append(ids, p.TaskID)
+		}
+		ts.Released++
+		if p.DeadlineMissed {
+			ts.Missed++
+		}
+		if p.State == models.Finished {
+			ts.Completed++
+			if response := p.FinishTime - p.ArrivalTime; response > ts.MaxResponse {
+				ts.MaxResponse = response
+			}
+		}
+	}
+	sort.Ints(ids)
+
+	stats := make([]models.TaskStats, 0, len(ids))
+	for _, id := range ids {
+		stats = append(stats, *byID[id])
+	}
+	return stats
+}
+
+// AnalyzeTasks 对当前的实时任务集做单处理机可调度性分析：
+// 速率单调调度的Liu-Layland利用率界和响应时间分析，以及最早截止时间优先的利用率（密度）测试
+func (s *Scheduler) AnalyzeTasks() *models.SchedulabilityReport {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return analyzeTasks(s.Tasks)
+}
+
+func analyzeTasks(tasks []*models.RealTimeTask) *models.SchedulabilityReport {
+	// 按速率单调优先级排序，周期相同时任务ID小的优先
+	ordered := models.CloneTasks(tasks)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Period != ordered[j].Period {
+			return ordered[i].Period < ordered[j].Period
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	report := &models.SchedulabilityReport{
+		Tasks:             make([]models.TaskAnalysis, 0, len(ordered)),
+		ImplicitDeadlines: true,
+		RMSchedulable:     true,
+	}
+	n := float64(len(ordered))
+	if n > 0 {
+		report.LiuLaylandBound = n * (math.Pow(2, 1/n) - 1)
+	}
+
+	for i, task := range ordered {
+		ta := models.TaskAnalysis{
+			ID:          task.ID,
+			Name:        task.Name,
+			Period:      task.Period,
+			WCET:        task.WCET,
+			Deadline:    task.Deadline,
+			Utilization: float64(task.WCET) / float64(task.Period),
+		}
+		report.Utilization += ta.Utilization
+		report.Density += float64(task.WCET) / float64(task.Deadline)
+		if task.Deadline != task.Period {
+			report.ImplicitDeadlines = false
+		}
+
+		ta.ResponseTime = responseTime(task, ordered[:i])
+		ta.Schedulable = ta.ResponseTime <= task.Deadline
+		if !ta.Schedulable {
+			report.RMSchedulable = false
+		}
+		report.Tasks = append(report.Tasks, ta)
+	}
+
+	report.RMBoundTest = report.ImplicitDeadlines && report.Utilization <= report.LiuLaylandBound
+	if report.ImplicitDeadlines {
+		report.EDFSchedulable = report.Utilization <= 1
+	} else {
+		report.EDFSchedulable = report.Density <= 1
+	}
+	return report
+}
+
+// responseTime 迭代求解 R = C + Σ ceil(R/Tj)·Cj，higher为优先级更高的任务。
+// 收敛时返回最坏响应时间，超过截止时间时停止迭代并返回当前值
+func responseTime(task *models.RealTimeTask, higher []*models.RealTimeTask) int {
+	r := task.WCET
+	for {
+		next := task.WCET
+		for _, h := range higher {
+			next += (r + h.Period - 1) / h.Period * h.WCET
+		}
+		if next == r || next > task.Deadline {
+			return next
+		}
+		r = next
+	}
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"math"
+	"os-scheduler-backend/models"
+	"testing"
+)
+
+// realTimeSystem 创建单处理机、按指定策略调度的系统，并添加周期任务
+func realTimeSystem(t *testing.T, policy models.SchedulingPolicy, tasks ...models.RealTimeTask) *Scheduler {
+	t.Helper()
+	s := newTestSystem(t, func(cfg *models.SystemConfig) {
+		cfg.ProcessorCount = 1
+		cfg.Policy = policy
+	})
+	for i := range tasks {
+		if err := s.AddTask(&tasks[i]); err != nil {
+			t.Fatalf("AddTask(%s): %v", tasks[i].Name, err)
+		}
+	}
+	return s
+}
+
+// missedJobs 返回各任务错过截止时刻的作业数
+func missedJobs(s *Scheduler) map[string]int {
+	missed := make(map[string]int)
+	for _, ts := range s.Stats().Tasks {
+		missed[ts.Name] = ts.Missed
+	}
+	return missed
+}
+
+func TestRMMissesWhereEDFSucceeds(t *testing.T) {
+	// 利用率 2/5+4/7≈0.97，超过Liu-Layland界但不超过1
+	tasks := []models.RealTimeTask{{Name: "t1", Period: 5, WCET: 2}, {Name: "t2", Period: 7, WCET: 4}}
+
+	rm := realTimeSystem(t, models.PolicyRM, tasks...)
+	edf := realTimeSystem(t, models.PolicyEDF, tasks...)
+	for i := 0; i < 36; i++ {
+		rm.Schedule()
+		edf.Schedule()
+	}
+	if missed := missedJobs(rm); missed["t1"] != 0 || missed["t2"] == 0 {
+		t.Errorf("rm missed %v, want only t2 to miss", missed)
+	}
+	if missed := missedJobs(edf); missed["t1"] != 0 || missed["t2"] != 0 {
+		t.Errorf("edf missed %v, want none", missed)
+	}
+}
+
+func TestAnalyzeTasks(t *testing.T) {
+	cases := []struct {
+		name      string
+		tasks     []models.RealTimeTask
+		responses []int
+		bound     bool
+		rm        bool
+		edf       bool
+	}{
+		{
+			name:      "under the bound",
+			tasks:     []models.RealTimeTask{{Period: 4, WCET: 1}, {Period: 6, WCET: 2}},
+			responses: []int{1, 3},
+			bound:     true, rm: true, edf: true,
+		},
+		{
+			// 超过利用率界，响应时间分析仍然可调度
+			name:      "harmonic",
+			tasks:     []models.RealTimeTask{{Period: 4, WCET: 2}, {Period: 8, WCET: 4}},
+			responses: []int{2, 8},
+			bound:     false, rm: true, edf: true,
+		},
+		{
+			name:      "rm fails",
+			tasks:     []models.RealTimeTask{{Period: 5, WCET: 2}, {Period: 7, WCET: 4}},
+			responses: []int{2, 8},
+			bound:     false, rm: false, edf: true,
+		},
+		{
+			name:      "constrained deadline",
+			tasks:     []models.RealTimeTask{{Period: 10, WCET: 3, Deadline: 4}, {Period: 10, WCET: 3, Deadline: 5}},
+			responses: []int{3, 6},
+			bound:     false, rm: false, edf: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := realTimeSystem(t, models.PolicyRM, tc.tasks...)
+			report := s.AnalyzeTasks()
+			for i, ta := range report.Tasks {
+				if ta.ResponseTime != tc.responses[i] {
+					t.Errorf("task %d response time = %d, want %d", ta.ID, ta.ResponseTime, tc.responses[i])
+				}
+			}
+			if report.RMBoundTest != tc.bound || report.RMSchedulable != tc.rm || report.EDFSchedulable != tc.edf {
+				t.Errorf("bound/rm/edf = %v/%v/%v, want %v/%v/%v",
+					report.RMBoundTest, report.RMSchedulable, report.EDFSchedulable, tc.bound, tc.rm, tc.edf)
+			}
+			if want := 2 * (math.Sqrt2 - 1); math.Abs(report.LiuLaylandBound-want) > 1e-9 {
+				t.Errorf("Liu-Layland bound = %v, want %v", report.LiuLaylandBound, want)
+			}
+		})
+	}
+}
+
+func TestAddTaskKeepsTaskOnError(t *testing.T) {
+	s := newTestSystem(t, nil)
+	task := &models.RealTimeTask{Name: "t", Period: 5, WCET: 6}
+	if err := s.AddTask(task); err == nil {
+		t.Fatal("AddTask accepted a WCET longer than the period")
+	}
+	if task.Deadline != 0 || task.ID != 0 || len(s.TaskList()) != 0 {
+		t.Errorf("rejected AddTask changed the task: %+v", task)
+	}
+
+	task.WCET = 2
+	if err := s.AddTask(task); err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	if task.Deadline != 5 || task.ID == 0 || task.Jobs != 1 {
+		t.Errorf("added task = %+v, want the deadline, ID and first job filled in", task)
+	}
+	// 调用者之后修改自己的任务不影响调度器
+	task.Period = 1
+	if got := s.TaskList()[0].Period; got != 5 {
+		t.Errorf("stored period = %d after the caller changed its copy, want 5", got)
+	}
+}
+
+func TestSporadicReleaseRespectsMinimumInterval(t *testing.T) {
+	s := newTestSystem(t, nil)
+	task := &models.RealTimeTask{Name: "s", Period: 4, WCET: 1, Sporadic: true}
+	if err := s.AddTask(task); err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	if _, err := s.ReleaseJob(task.ID); err != nil {
+		t.Fatalf("ReleaseJob: %v", err)
+	}
+	s.Schedule()
+	if _, err := s.ReleaseJob(task.ID); err == nil {
+		t.Error("ReleaseJob released a second job within the minimum interval")
+	}
+	for s.Clock < 4 {
+		s.Schedule()
+	}
+	if _, err := s.ReleaseJob(task.ID); err != nil {
+		t.Errorf("ReleaseJob after the interval: %v", err)
+	}
+}
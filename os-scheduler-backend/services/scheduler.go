@@ -3,192 +3,497 @@ package services
 import (
 	"fmt"
 	"os-scheduler-backend/models"
-	"sort"
 	"sync"
 )
 
 type Scheduler struct {
 	Queue          *models.ProcessQueue
 	ProcessorCount int
+	Processors     []models.ProcessorSpec // 各处理机的规格，长度与处理机数量相同
 	MaxProcesses   int
-	mutex          sync.Mutex
-	nextPID        int
-	memoryManager  *MemoryManager // 添加内存管理器字段
+	Policy         models.SchedulingPolicy // 调度策略
+	TimeQuantum    int                     // 时间片轮转的时间片长度
+
+	PerProcessorQueues bool // 每个处理机使用独立的就绪队列
+	MigrationCost      int  // 进程换到另一个处理机时额外占用的时钟周期数
+	BalanceInterval    int  // 独立就绪队列之间负载均衡的间隔
+
+	Seed        uint64 // 彩票调度使用的随机数种子
+	randomState uint64 // 随机数生成器的当前状态
+
+	TargetLatency  int          // 完全公平调度的目标延迟
+	MinGranularity int          // 完全公平调度每次占用处理机的最短时间
+	MinVRuntime    int          // 可运行进程的最小虚拟运行时间
+	cfs            *cfsRunQueue // 完全公平调度按虚拟运行时间排序的就绪进程
+
+	AgingInterval int  // 动态优先数策略下进程每连续等待多少个时钟周期提高一次优先数，0表示不老化
+	AgingStep     int  // 每次老化增加的优先数
+	AgeBackup     bool // 后备队列中的进程也参与老化，并按优先数调入
+
+	LockProtocol models.LockProtocol // 锁协议
+
+	ContextSwitchCost int   // 处理机换一个进程运行时上下文切换占用的时钟周期数
+	loaded            []int // 各处理机上最近运行过的进程，由时间线导出
+
+	GroupShares map[string]int // 公平份额调度中各用户组的份额
+
+	Clock         int                    // 已执行的时钟周期数
+	Timeline      []models.TimelineSlot  // 每个时钟周期各处理机上运行的进程
+	Events        []models.TimelineEvent // 时间线上的系统事件，如处理机数量变化
+	Tasks         []*models.RealTimeTask // 实时任务
+	started       bool                   // 是否已做出过调度决策
+	mutex         sync.Mutex
+	nextPID       int
+	nextTaskID    int
+	memoryManager *MemoryManager // 添加内存管理器字段
+
+	// 自动保存快照
+	autosavePath  string
+	autosaveEvery int
+
+	history  *snapshotRing // 每次调度前的状态，用于回退
+	eventLog *EventLog     // 记录改变状态的调用，为空时不记录
 }
 
 func NewScheduler(processorCount, maxProcesses int, mm *MemoryManager) *Scheduler {
 	return &Scheduler{
-		Queue: &models.ProcessQueue{
-			Ready:     make([]*models.PCB, 0),
-			Running:   make([]*models.PCB, 0),
-			Waiting:   make([]*models.PCB, 0),
-			Backup:    make([]*models.PCB, 0),
-			Suspended: make([]*models.PCB, 0),
-		},
-		ProcessorCount: processorCount,
-		MaxProcesses:   maxProcesses,
-		nextPID:        1,
-		memoryManager:  mm, // 初始化内存管理器
+		Queue:           newProcessQueue(),
+		ProcessorCount:  processorCount,
+		Processors:      normalizeProcessors(nil, processorCount),
+		MaxProcesses:    maxProcesses,
+		Policy:          models.PolicyPriority,
+		TimeQuantum:     DefaultTimeQuantum,
+		BalanceInterval: DefaultBalanceInterval,
+		TargetLatency:   DefaultTargetLatency,
+		MinGranularity:  DefaultMinGranularity,
+		AgingStep:       DefaultAgingStep,
+		LockProtocol:    models.LockNone,
+		Timeline:        make([]models.TimelineSlot, 0),
+		Events:          make([]models.TimelineEvent, 0),
+		Tasks:           make([]*models.RealTimeTask, 0),
+		nextPID:         1,
+		nextTaskID:      1,
+		memoryManager:   mm, // 初始化内存管理器
+		history:         newSnapshotRing(DefaultHistorySize),
+	}
+}
+
+func newProcessQueue() *models.ProcessQueue {
+	return &models.ProcessQueue{
+		New:       make([]*models.PCB, 0),
+		Ready:     make([]*models.PCB, 0),
+		Running:   make([]*models.PCB, 0),
+		Waiting:   make([]*models.PCB, 0),
+		Backup:    make([]*models.PCB, 0),
+		Suspended: make([]*models.PCB, 0),
+		Finished:  make([]*models.PCB, 0),
 	}
 }
 
-func (s *Scheduler) AddProcess(process *models.PCB) {
+// Reset 清空所有进程并释放全部内存，系统参数保持不变
+func (s *Scheduler) Reset() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	process.PID = s.nextPID
+	s.reset()
+	s.eventLog.Record(OpReset, nil)
+}
+
+func (s *Scheduler) reset() {
+	s.Queue = newProcessQueue()
+	s.Clock = 0
+	s.Timeline = make([]models.TimelineSlot, 0)
+	s.Events = make([]models.TimelineEvent, 0)
+	s.Tasks = make([]*models.RealTimeTask, 0)
+	s.started = false
+	s.nextPID = 1
+	s.nextTaskID = 1
+	s.randomState = s.Seed
+	s.MinVRuntime = 0
+	s.cfs = nil
+	s.loaded = nil
+	s.memoryManager.Reset()
+	s.history.clear()
+}
+
+// AddProcess 校验新进程并为其分配内存后加入系统，前驱关系无效时返回 *PrecedenceError。
+// 只采用提交者可以指定的字段，内存起始地址、运行时间、后继、虚拟运行时间等由调度器维护的字段都重新初始化
+func (s *Scheduler) AddProcess(process *models.PCB) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	submitted := process.Clone()
+	*process = models.PCB{
+		Name:              submitted.Name,
+		PID:               s.nextPID,
+		RequiredTime:      submitted.RequiredTime,
+		TotalRequiredTime: submitted.RequiredTime,
+		Priority:          submitted.Priority,
+		InitialPriority:   submitted.Priority,
+		MemorySize:        submitted.MemorySize,
+		MemoryStart:       -1,
+		ProcessorID:       -1,
+		Predecessors:      uniquePIDs(submitted.Predecessors),
+		ArrivalTime:       s.Clock,
+		StartTime:         -1,
+		AffinityMask:      submitted.AffinityMask,
+		LastProcessor:     -1,
+		RunQueue:          -1,
+		Gang:              submitted.Gang,
+		Tickets:           submitted.Tickets,
+		CriticalSections:  submitted.CriticalSections,
+		User:              submitted.User,
+		Group:             submitted.Group,
+	}
+	if err := s.validateSpec(fmt.Sprint(process.PID), process.RequiredTime, process.MemorySize, process.AffinityMask, process.Tickets); err != nil {
+		return err
+	}
+	if err := validateSections(fmt.Sprint(process.PID), process.CriticalSections, process.RequiredTime); err != nil {
+		return err
+	}
+	if err := s.validatePrecedence([]*models.PCB{process}); err != nil {
+		return err
+	}
+	if err := s.checkNewGangs([]*models.PCB{process}); err != nil {
+		return err
+	}
+	start, err := s.memoryManager.allocate(process.MemorySize)
+	if err != nil {
+		return fmt.Errorf("内存分配失败: %w", err)
+	}
+	process.MemoryStart = start
 	s.nextPID++
 
-	// 更新前驱进程的后继列表
-	for _, predPID := range process.Predecessors {
-		// 在所有队列中查找前驱进程
-		found := false
-		// 检查就绪队列
-		for _, p := range s.Queue.Ready {
-			if p.PID == predPID {
-				p.Successors = append(p.Successors, process.PID)
-				found = true
-				break
-			}
-		}
-		if !found {
-			// 检查运行队列
-			for _, p := range s.Queue.Running {
-				if p.PID == predPID {
-					p.Successors = append(p.Successors, process.PID)
-					found = true
-					break
-				}
-			}
+	s.insertProcesses([]*models.PCB{process})
+	s.eventLog.Record(OpAddProcess, addProcessArgs{Process: submitted, PID: process.PID})
+	return nil
+}
+
+// insertProcesses 将已校验的进程依次放入相应队列，前驱须先于后继放入。
+// 前驱通过PID索引查找，就绪队列在全部放入后只排序一次
+func (s *Scheduler) insertProcesses(processes []*models.PCB) {
+	index := s.processIndex()
+	for _, process := range processes {
+		index[process.PID] = process
+	}
+	sortReady := false
+	for _, process := range processes {
+		if s.insertProcess(process, index) {
+			sortReady = true
 		}
-		if !found {
-			// 检查等待队列
-			for _, p := range s.Queue.Waiting {
-				if p.PID == predPID {
-					p.Successors = append(p.Successors, process.PID)
-					found = true
-					break
-				}
-			}
+	}
+	if sortReady {
+		s.sortReadyQueue()
+	}
+}
+
+// insertProcess 将已校验的进程放入相应队列，返回是否进入了就绪队列
+func (s *Scheduler) insertProcess(process *models.PCB, index map[int]*models.PCB) bool {
+	// 更新前驱进程的后继列表，并统计尚未完成的前驱
+	pending := 0
+	for _, predPID := range process.Predecessors {
+		pred := index[predPID]
+		if pred == nil {
+			continue
 		}
-		if !found {
-			// 检查后备队列
-			for _, p := range s.Queue.Backup {
-				if p.PID == predPID {
-					p.Successors = append(p.Successors, process.PID)
-					found = true
-					break
-				}
-			}
+		pred.Successors = append(pred.Successors, process.PID)
+		if pred.State != models.Finished {
+			pending++
 		}
 	}
 
-	// 检查前驱进程是否都已完成
-	if len(process.Predecessors) > 0 {
-		process.State = models.Waiting
+	// 已完成的前驱视为满足，只有存在未完成的前驱时才进入等待队列
+	process.ProcessorID = -1
+	if pending > 0 {
+		s.transition(process, models.Waiting, s.Clock, "等待前驱完成")
 		s.Queue.Waiting = append(s.Queue.Waiting, process)
+	} else if len(s.Queue.Ready)+len(s.Queue.Running) < s.MaxProcesses {
+		s.transition(process, models.Ready, s.Clock, "进入就绪队列")
+		s.Queue.Ready = append(s.Queue.Ready, process)
+		return true
 	} else {
-		process.State = models.Ready
-		if len(s.Queue.Ready)+len(s.Queue.Running) < s.MaxProcesses {
-			s.Queue.Ready = append(s.Queue.Ready, process)
-			s.sortReadyQueue()
-		} else {
-			s.Queue.Backup = append(s.Queue.Backup, process)
-		}
+		s.transition(process, models.Ready, s.Clock, "道数已满，进入后备队列")
+		s.Queue.Backup = append(s.Queue.Backup, process)
 	}
-	process.ProcessorID = -1
+	return false
 }
 
+// Schedule 推进一个时钟周期：上一次选中的进程各运行一个时间单位，
+// 然后接纳已到达的进程并按调度策略重新分配处理机
 func (s *Scheduler) Schedule() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	// 1. 处理运行中的进程
-	for _, p := range s.Queue.Running {
-		p.Priority--
-		p.RequiredTime--
+	if s.history.enabled() {
+		s.history.push(historyEntry{snap: s.stateSnapshot(), timeline: len(s.Timeline), events: len(s.Events)})
+	}
+
+	// 1. 处理运行中的进程；第一次调度之前还没有进程被选中，不推进时钟
+	if s.started {
+		s.runTick()
+		s.checkDeadlines()
+	}
+	s.started = true
+
+	// 2. 释放到期的实时作业，接纳已到达的新进程
+	s.releaseJobs()
+	s.admitArrivals()
+
+	// 3. 按调度策略重新分配处理机
+	s.dispatch()
+
+	// 4. 从后备队列调入新进程
+	s.admitBackup()
+
+	s.eventLog.Record(OpSchedule, scheduleArgs{Clock: s.Clock})
+	s.autosave()
+}
+
+// runTick 运行队列中的进程各执行一个时间单位，记录时间线并推进时钟
+func (s *Scheduler) runTick() {
+	slot := models.TimelineSlot{Tick: s.Clock, Processors: make([]int, s.ProcessorCount)}
+	for i := range slot.Processors {
+		slot.Processors[i] = -1
+	}
+
+	s.accrueEntitlement()
+	fair := s.fairStrides()
+
+	running := append([]*models.PCB(nil), s.Queue.Running...)
+	for _, p := range running {
+		if p.ProcessorID >= 0 && p.ProcessorID < len(slot.Processors) {
+			slot.Processors[p.ProcessorID] = p.PID
+		}
+		if p.SwitchTicks > 0 {
+			// 上下文切换开销：占用处理机但不推进，不计入时间片
+			p.SwitchTicks--
+			if p.ProcessorID >= 0 && p.ProcessorID < len(slot.Processors) {
+				if slot.Switching == nil {
+					slot.Switching = make([]bool, len(slot.Processors))
+				}
+				slot.Switching[p.ProcessorID] = true
+			}
+			continue
+		}
+		if p.StallTicks > 0 {
+			// 迁移开销：占用处理机但不推进
+			p.StallTicks--
+			p.QuantumUsed++
+			continue
+		}
+		cs, blocked := s.enterSection(p)
+		if blocked {
+			continue
+		}
+		if s.Policy == models.PolicyPriority {
+			// 锁协议提升优先数期间有效优先数不变，降低的是基础优先数
+			if p.Boost > 0 {
+				p.Boost++
+			} else {
+				p.Priority--
+			}
+		}
+		// 按处理机速度推进，不足一个时间单位的部分累积到下一个时钟周期；
+		// 不越过临界区的边界，超出的工作量作废
+		work := p.WorkCarry + s.speed(p.ProcessorID)
+		if limit := sectionLimit(p, cs); limit >= 0 && progress(p)+work/1000 > limit {
+			work = (limit - progress(p)) * 1000
+		}
+		p.RequiredTime -= work / 1000
+		p.WorkCarry = work % 1000
+		p.CPUTime++
+		p.QuantumUsed++
+		p.Pass += stride(p)
+		p.VRuntime += vruntimeDelta(p)
+		p.FairPass += fair[p.PID]
+		if cs != nil && p.HeldLock == cs.Lock && progress(p) >= cs.Start+cs.Length {
+			s.releaseLock(p)
+		}
 
 		if p.RequiredTime <= 0 {
+			p.RequiredTime = 0
+			p.WorkCarry = 0
 			// 进程完成，移出运行队列
+			if s.transition(p, models.Finished, s.Clock+1, "运行完成") != nil {
+				continue
+			}
 			s.removeFromRunning(p)
-			p.State = models.Finished
+			p.FinishTime = s.Clock + 1
+			p.ProcessorID = -1
+			s.Queue.Finished = append(s.Queue.Finished, p)
 
 			// 释放内存
-			s.memoryManager.Free(p.MemoryStart)
+			s.memoryManager.free(p.MemoryStart)
 
 			// 检查是否有等待此进程完成的其他进程
 			s.checkWaitingProcesses(p.PID)
-		} else {
-			// 进程未完成，放回就绪队列以便重新参与调度
-			s.removeFromRunning(p)
-			p.State = models.Ready
-			p.ProcessorID = -1
-			s.Queue.Ready = append(s.Queue.Ready, p)
 		}
 	}
 
-	// 2. 重新排序就绪队列
+	s.age()
+	s.countLockWaits()
+	s.loadedProcesses()
+	s.load(slot)
+	s.Timeline = append(s.Timeline, slot)
+	s.Clock++
+}
+
+// dispatch 让不再保留处理机的进程回到就绪队列，再把空闲处理机分配给就绪队列中的进程
+func (s *Scheduler) dispatch() {
+	s.applyLockProtocol()
+	for _, p := range append([]*models.PCB(nil), s.Queue.Running...) {
+		// 进程未完成，放回就绪队列以便重新参与调度
+		switch {
+		case !allowedOn(p, p.ProcessorID):
+			s.preempt(p, fmt.Sprintf("亲和性不允许在处理机 %d 上运行", p.ProcessorID))
+		case !s.keepsProcessor(p):
+			s.preempt(p, "重新调度")
+		}
+	}
+	// 进程组中有进程让出处理机或不在运行时，整个组一起让出处理机
+	for _, p := range append([]*models.PCB(nil), s.Queue.Running...) {
+		if p.Gang != "" && !s.gangRunning(p.Gang) {
+			s.preempt(p, fmt.Sprintf("进程组 %s 整组让出处理机", p.Gang))
+		}
+	}
+
 	s.sortReadyQueue()
+	if s.Policy == models.PolicyLottery {
+		s.drawLottery()
+	}
 
-	// 3. 为所有处理机重新分配进程
-	for i := 0; i < s.ProcessorCount; i++ {
-		if len(s.Queue.Ready) > 0 {
-			process := s.Queue.Ready[0]
-			s.Queue.Ready = s.Queue.Ready[1:]
-			process.State = models.Running
-			process.ProcessorID = i
-			s.Queue.Running = append(s.Queue.Running, process)
+	busy := make([]bool, s.ProcessorCount)
+	for _, p := range s.Queue.Running {
+		if p.ProcessorID >= 0 && p.ProcessorID < s.ProcessorCount {
+			busy[p.ProcessorID] = true
 		}
 	}
+	if s.Policy != models.PolicyCFS {
+		s.cfs = nil
+	}
+	switch {
+	case s.PerProcessorQueues:
+		s.assignPerProcessor(busy)
+	case s.Policy == models.PolicyCFS:
+		s.assignCFS(busy)
+	default:
+		s.assignGlobal(busy)
+	}
+}
 
-	// 4. 从后备队列调入新进程
-	for len(s.Queue.Ready)+len(s.Queue.Running) < s.MaxProcesses && len(s.Queue.Backup) > 0 {
-		process := s.Queue.Backup[0]
-		s.Queue.Backup = s.Queue.Backup[1:]
-		s.Queue.Ready = append(s.Queue.Ready, process)
+// admitBackup 在道数允许的范围内从后备队列调入进程。进程组整组调入：
+// 组内还有进程未就绪或在后备队列中时，组内就绪队列中的进程退回后备队列，不占用道数；
+// 组内进程都已就绪时，道数足够才把组内后备队列中的进程一起调入，不够时不再调入排在后面的进程
+func (s *Scheduler) admitBackup() {
+	gangs := s.gangPlacements()
+	ready := make([]*models.PCB, 0, len(s.Queue.Ready))
+	for _, p := range s.Queue.Ready {
+		if g := gangs[p.Gang]; p.Gang != "" && (g.backup > 0 || g.pending > 0) {
+			s.Queue.Backup = append(s.Queue.Backup, p)
+			g.backup++
+			continue
+		}
+		ready = append(ready, p)
+	}
+	admitted := len(ready) != len(s.Queue.Ready)
+	s.Queue.Ready = ready
+
+	skipped := make(map[int]bool)
+	for len(s.Queue.Backup) > 0 {
+		i := s.nextBackup(skipped)
+		if i < 0 {
+			break
+		}
+		process := s.Queue.Backup[i]
+		room := s.MaxProcesses - len(s.Queue.Ready) - len(s.Queue.Running)
+		if process.Gang == "" {
+			if room <= 0 {
+				break
+			}
+			s.Queue.Backup = append(s.Queue.Backup[:i], s.Queue.Backup[i+1:]...)
+			s.Queue.Ready = append(s.Queue.Ready, process)
+			admitted = true
+			continue
+		}
+
+		g := gangs[process.Gang]
+		if g.pending > 0 {
+			// 组内还有进程未就绪，跳过整个组
+			for _, p := range s.Queue.Backup {
+				if p.Gang == process.Gang {
+					skipped[p.PID] = true
+				}
+			}
+			continue
+		}
+		if g.backup > room {
+			break
+		}
+		backup := make([]*models.PCB, 0, len(s.Queue.Backup))
+		for _, p := range s.Queue.Backup {
+			if p.Gang == process.Gang {
+				s.Queue.Ready = append(s.Queue.Ready, p)
+			} else {
+				backup = append(backup, p)
+			}
+		}
+		s.Queue.Backup = backup
+		g.backup = 0
+		admitted = true
+	}
+	if admitted {
 		s.sortReadyQueue()
 	}
 }
 
+// gangPlacement 进程组中尚未完成的进程所在的位置
+type gangPlacement struct {
+	backup  int // 在后备队列中的进程数
+	pending int // 尚未就绪（新建、等待或挂起）的进程数
+}
+
+// gangPlacements 统计各进程组中在后备队列中和尚未就绪的进程数
+func (s *Scheduler) gangPlacements() map[string]*gangPlacement {
+	placements := make(map[string]*gangPlacement)
+	count := func(processes []*models.PCB, backup bool) {
+		for _, p := range processes {
+			if p.Gang == "" {
+				continue
+			}
+			g, ok := placements[p.Gang]
+			if !ok {
+				g = &gangPlacement{}
+				placements[p.Gang] = g
+			}
+			switch {
+			case backup:
+				g.backup++
+			case p.State != models.Ready && p.State != models.Running:
+				g.pending++
+			}
+		}
+	}
+	count(s.Queue.Ready, false)
+	count(s.Queue.Running, false)
+	count(s.Queue.Backup, true)
+	count(s.Queue.New, false)
+	count(s.Queue.Waiting, false)
+	count(s.Queue.Suspended, false)
+	return placements
+}
+
 // 检查等待队列中的进程是否可以就绪
 func (s *Scheduler) checkWaitingProcesses(finishedPID int) {
 	var readyProcesses []*models.PCB
 	remainingWaiting := make([]*models.PCB, 0)
+	index := s.processIndex()
 
 	// 遍历等待队列中的所有进程
 	for _, p := range s.Queue.Waiting {
-		canReady := true
-		// 检查该进程的所有前驱是否都已完成
-		for _, predPID := range p.Predecessors {
-			found := false
-			// 在所有队列中查找前驱进程
-			for _, rp := range s.Queue.Running {
-				if rp.PID == predPID {
-					found = true
-					break
-				}
-			}
-			for _, rp := range s.Queue.Ready {
-				if rp.PID == predPID {
-					found = true
-					break
-				}
-			}
-			for _, rp := range s.Queue.Waiting {
-				if rp.PID == predPID {
-					found = true
-					break
-				}
-			}
-			// 如果找到任何一个前驱进程还在运行，则该进程不能就绪
-			if found {
-				canReady = false
-				break
-			}
-		}
+		// 因等待锁阻塞的进程在锁释放时才就绪
+		canReady := p.BlockedOn == "" && predecessorsFinished(p, index)
 
-		if canReady {
-			p.State = models.Ready
+		if canReady && s.transition(p, models.Ready, s.Clock+1, "前驱全部完成") == nil {
 			readyProcesses = append(readyProcesses, p)
 		} else {
 			remainingWaiting = append(remainingWaiting, p)
@@ -200,10 +505,13 @@ func (s *Scheduler) checkWaitingProcesses(finishedPID int) {
 
 	// 将可以就绪的进程添加到就绪队列或后备队列
 	for _, p := range readyProcesses {
-		if len(s.Queue.Ready)+len(s.Queue.Running) < s.MaxProcesses {
-			s.Queue.Ready = append(s.Queue.Ready, p)
-		} else {
-			s.Queue.Backup = append(s.Queue.Backup, p)
+		s.enqueueReady(p)
+	}
+
+	// 挂起阻塞的进程记下前驱已全部完成，恢复时直接回到就绪队列
+	for _, p := range s.Queue.Suspended {
+		if p.State == models.SuspendedBlocked && p.BlockedOn == "" && predecessorsFinished(p, index) {
+			s.transition(p, models.SuspendedReady, s.Clock+1, "前驱全部完成")
 		}
 	}
 
@@ -212,10 +520,68 @@ func (s *Scheduler) checkWaitingProcesses(finishedPID int) {
 	}
 }
 
-func (s *Scheduler) sortReadyQueue() {
-	sort.Slice(s.Queue.Ready, func(i, j int) bool {
-		return s.Queue.Ready[i].Priority > s.Queue.Ready[j].Priority
-	})
+// enqueueReady 将就绪的进程加入就绪队列，道数已满时加入后备队列
+func (s *Scheduler) enqueueReady(p *models.PCB) {
+	if len(s.Queue.Ready)+len(s.Queue.Running) < s.MaxProcesses {
+		s.Queue.Ready = append(s.Queue.Ready, p)
+	} else {
+		s.Queue.Backup = append(s.Queue.Backup, p)
+	}
+}
+
+// predecessorsFinished 判断进程的所有前驱是否都已完成，前驱在PID索引中查找
+func predecessorsFinished(process *models.PCB, index map[int]*models.PCB) bool {
+	for _, predPID := range process.Predecessors {
+		pred := index[predPID]
+		if pred != nil && pred.State != models.Finished {
+			return false
+		}
+	}
+	return true
+}
+
+// allProcesses 返回所有队列中的进程
+func (s *Scheduler) allProcesses() []*models.PCB {
+	all := make([]*models.PCB, 0)
+	all = append(all, s.Queue.New...)
+	all = append(all, s.Queue.Ready...)
+	all = append(all, s.Queue.Running...)
+	all = append(all, s.Queue.Waiting...)
+	all = append(all, s.Queue.Backup...)
+	all = append(all, s.Queue.Suspended...)
+	all = append(all, s.Queue.Finished...)
+	return all
+}
+
+// processIndex 返回所有队列中的进程按PID的索引，需要多次查找进程时使用
+func (s *Scheduler) processIndex() map[int]*models.PCB {
+	all := s.allProcesses()
+	index := make(map[int]*models.PCB, len(all))
+	for _, p := range all {
+		index[p.PID] = p
+	}
+	return index
+}
+
+// findProcess 在所有队列中查找指定PID的进程，找不到时返回nil
+func (s *Scheduler) findProcess(pid int) *models.PCB {
+	for _, p := range s.allProcesses() {
+		if p.PID == pid {
+			return p
+		}
+	}
+	return nil
+}
+
+// preempt 让运行中的进程让出处理机，回到就绪队列
+func (s *Scheduler) preempt(p *models.PCB, reason string) {
+	if s.transition(p, models.Ready, s.Clock, reason) != nil {
+		return
+	}
+	s.removeFromRunning(p)
+	p.ProcessorID = -1
+	p.QuantumUsed = 0
+	s.Queue.Ready = append(s.Queue.Ready, p)
 }
 
 func (s *Scheduler) removeFromRunning(process *models.PCB) {
@@ -227,27 +593,35 @@ func (s *Scheduler) removeFromRunning(process *models.PCB) {
 	}
 }
 
-// SuspendProcess 将指定进程挂起
+// SuspendProcess 将指定进程挂起。就绪、运行和后备队列中的进程转为挂起就绪，
+// 等待队列中的进程转为挂起阻塞
 func (s *Scheduler) SuspendProcess(pid int) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	// 在就绪队列中查找进程
-	for i, p := range s.Queue.Ready {
-		if p.PID == pid {
-			p.State = models.Suspended
-			s.Queue.Suspended = append(s.Queue.Suspended, p)
-			s.Queue.Ready = append(s.Queue.Ready[:i], s.Queue.Ready[i+1:]...)
-			return nil
-		}
+	queues := []struct {
+		queue *[]*models.PCB
+		state models.ProcessState
+	}{
+		{&s.Queue.Ready, models.SuspendedReady},
+		{&s.Queue.Running, models.SuspendedReady},
+		{&s.Queue.Backup, models.SuspendedReady},
+		{&s.Queue.Waiting, models.SuspendedBlocked},
 	}
-
-	// 在运行队列中查找进程
-	for i, p := range s.Queue.Running {
-		if p.PID == pid {
-			p.State = models.Suspended
+	for _, q := range queues {
+		for i, p := range *q.queue {
+			if p.PID != pid {
+				continue
+			}
+			if err := s.transition(p, q.state, s.Clock, "挂起"); err != nil {
+				return err
+			}
+			*q.queue = append((*q.queue)[:i], (*q.queue)[i+1:]...)
+			// 运行中的进程让出处理机
+			p.ProcessorID = -1
+			p.QuantumUsed = 0
 			s.Queue.Suspended = append(s.Queue.Suspended, p)
-			s.Queue.Running = append(s.Queue.Running[:i], s.Queue.Running[i+1:]...)
+			s.eventLog.Record(OpSuspend, pidArgs{PID: pid})
 			return nil
 		}
 	}
@@ -255,7 +629,8 @@ func (s *Scheduler) SuspendProcess(pid int) error {
 	return fmt.Errorf("找不到进程 %d", pid)
 }
 
-// ResumeProcess 恢复被挂起的进程
+// ResumeProcess 恢复被挂起的进程：挂起就绪的进程回到就绪队列（道数已满时进入后备队列），
+// 挂起阻塞的进程回到等待队列继续等待
 func (s *Scheduler) ResumeProcess(pid int) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -263,10 +638,21 @@ func (s *Scheduler) ResumeProcess(pid int) error {
 	// 在挂起队列中查找进程
 	for i, p := range s.Queue.Suspended {
 		if p.PID == pid {
-			p.State = models.Ready
-			s.Queue.Ready = append(s.Queue.Ready, p)
+			to, reason := models.Ready, "恢复"
+			if p.State == models.SuspendedBlocked {
+				to, reason = models.Waiting, "恢复，继续等待"
+			}
+			if err := s.transition(p, to, s.Clock, reason); err != nil {
+				return err
+			}
 			s.Queue.Suspended = append(s.Queue.Suspended[:i], s.Queue.Suspended[i+1:]...)
-			s.sortReadyQueue() // 重新排序就绪队列
+			if to == models.Waiting {
+				s.Queue.Waiting = append(s.Queue.Waiting, p)
+			} else {
+				s.enqueueReady(p)
+				s.sortReadyQueue() // 重新排序就绪队列
+			}
+			s.eventLog.Record(OpResume, pidArgs{PID: pid})
 			return nil
 		}
 	}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"fmt"
+	"os-scheduler-backend/models"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// newTestSystem 按默认系统参数创建调度器，modify不为空时先修改参数
+func newTestSystem(t *testing.T, modify func(cfg *models.SystemConfig)) *Scheduler {
+	t.Helper()
+	cfg := DefaultConfig()
+	if modify != nil {
+		modify(&cfg)
+	}
+	s, err := NewSystem(cfg)
+	if err != nil {
+		t.Fatalf("NewSystem: %v", err)
+	}
+	return s
+}
+
+// addTestProcess 添加一个进程并返回其PID
+func addTestProcess(t *testing.T, s *Scheduler, p *models.PCB) int {
+	t.Helper()
+	if err := s.AddProcess(p); err != nil {
+		t.Fatalf("AddProcess(%s): %v", p.Name, err)
+	}
+	return p.PID
+}
+
+func TestAddProcessIgnoresSchedulerFields(t *testing.T) {
+	s := newTestSystem(t, nil)
+	pred := addTestProcess(t, s, &models.PCB{Name: "pred", RequiredTime: 2})
+	pid := addTestProcess(t, s, &models.PCB{
+		Name:              "forged",
+		RequiredTime:      5,
+		TotalRequiredTime: 50,
+		Priority:          3,
+		Successors:        []int{pred},
+		CPUTime:           40,
+		VRuntime:          1000,
+		FairPass:          1000,
+		Pass:              1000,
+		WorkCarry:         999,
+		SwitchTicks:       3,
+		StallTicks:        3,
+		QuantumUsed:       2,
+		TaskID:            7,
+		Deadline:          9,
+		FinishTime:        4,
+		State:             models.Finished,
+		HeldLock:          "bus",
+		Boost:             5,
+	})
+
+	p := s.findProcess(pid)
+	if p.State != models.Ready {
+		t.Fatalf("state = %s, want ready", p.State)
+	}
+	if p.TotalRequiredTime != 5 || p.CPUTime != 0 || progress(p) != 0 {
+		t.Errorf("run time not reset: total=%d cpu=%d", p.TotalRequiredTime, p.CPUTime)
+	}
+	if len(p.Successors) != 0 {
+		t.Errorf("successors = %v, want none", p.Successors)
+	}
+	if p.VRuntime != 0 || p.FairPass != 0 || p.Pass != 0 || p.WorkCarry != 0 {
+		t.Errorf("accounting not reset: %+v", p)
+	}
+	if p.SwitchTicks != 0 || p.StallTicks != 0 || p.QuantumUsed != 0 {
+		t.Errorf("overhead not reset: switch=%d stall=%d quantum=%d", p.SwitchTicks, p.StallTicks, p.QuantumUsed)
+	}
+	if p.TaskID != 0 || p.Deadline != 0 || p.FinishTime != 0 || p.HeldLock != "" || p.Boost != 0 {
+		t.Errorf("task or lock fields not reset: %+v", p)
+	}
+	if p.Priority != 3 || p.Name != "forged" {
+		t.Errorf("submitted fields lost: %+v", p)
+	}
+}
+
+func TestAddProcessAllocatesMemory(t *testing.T) {
+	s := newTestSystem(t, nil)
+	before := s.Snapshot().Memory
+
+	if err := s.AddProcess(&models.PCB{Name: "bad", RequiredTime: 2, MemorySize: 100, Predecessors: []int{42}}); err == nil {
+		t.Fatal("AddProcess accepted an unknown predecessor")
+	}
+	if err := s.AddProcess(&models.PCB{Name: "huge", RequiredTime: 2, MemorySize: 1 << 20}); err == nil {
+		t.Fatal("AddProcess accepted more memory than the user area")
+	}
+	if got := s.Snapshot().Memory; !reflect.DeepEqual(got, before) {
+		t.Fatalf("rejected processes changed memory: %+v", got.Blocks)
+	}
+
+	// 并发添加的进程各自分配到不重叠的内存
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := s.AddProcess(&models.PCB{Name: fmt.Sprint(i), RequiredTime: 2, MemorySize: 100, MemoryStart: 7}); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	starts := make(map[int]bool)
+	for _, p := range s.allProcesses() {
+		if p.MemoryStart < 0 || starts[p.MemoryStart] {
+			t.Fatalf("process %d got memory at %d", p.PID, p.MemoryStart)
+		}
+		starts[p.MemoryStart] = true
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os-scheduler-backend/models"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultSessionID 默认会话的ID，不带会话前缀的接口都作用于默认会话
+const DefaultSessionID = "default"
+
+// DefaultMaxSessions 默认允许同时存在的最大会话数
+const DefaultMaxSessions = 100
+
+var sessionIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// Session 一个独立的模拟会话，拥有自己的调度器和内存管理器
+type Session struct {
+	ID        string
+	CreatedAt time.Time
+	Scheduler *Scheduler
+}
+
+// Info 返回会话的概要信息
+func (sess *Session) Info() models.SessionInfo {
+	s := sess.Scheduler
+	s.mutex.Lock()
+	clock, processes := s.Clock, len(s.allProcesses())
+	s.mutex.Unlock()
+
+	return models.SessionInfo{
+		ID:        sess.ID,
+		CreatedAt: sess.CreatedAt,
+		Config:    s.Config(),
+		Clock:     clock,
+		Processes: processes,
+	}
+}
+
+// SessionManager 按会话ID管理相互隔离的模拟会话
+type SessionManager struct {
+	mutex       sync.RWMutex
+	sessions    map[string]*Session
+	maxSessions int
+}
+
+// NewSessionManager 创建会话管理器，maxSessions<=0时使用默认上限
+func NewSessionManager(maxSessions int) *SessionManager {
+	if maxSessions <= 0 {
+		maxSessions = DefaultMaxSessions
+	}
+	return &SessionManager{
+		sessions:    make(map[string]*Session),
+		maxSessions: maxSessions,
+	}
+}
+
+// Create 按系统参数创建新会话，id为空时自动生成
+func (m *SessionManager) Create(id string, cfg models.SystemConfig) (*Session, error) {
+	if id == "" {
+		id = newSessionID()
+	}
+	if !sessionIDPattern.MatchString(id) {
+		return nil, errors.New("会话ID只能包含字母、数字、下划线和连字符，长度不超过64")
+	}
+
+	scheduler, err := NewSystem(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.sessions[id]; ok {
+		return nil, fmt.Errorf("会话 %s 已存在", id)
+	}
+	if len(m.sessions) >= m.maxSessions {
+		return nil, fmt.Errorf("会话数已达到上限 %d", m.maxSessions)
+	}
+	session := &Session{ID: id, CreatedAt: time.Now(), Scheduler: scheduler}
+	m.sessions[id] = session
+	return session, nil
+}
+
+// Get 查找会话
+func (m *SessionManager) Get(id string) (*Session, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	session, ok := m.sessions[id]
+	return session, ok
+}
+
+// List 返回所有会话，按创建时间排序
+func (m *SessionManager) List() []*Session {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, session := range m.sessions {
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].CreatedAt.Equal(sessions[j].CreatedAt) {
+			return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+		}
+		return sessions[i].ID < sessions[j].ID
+	})
+	return sessions
+}
+
+// Delete 删除会话，默认会话不能删除
+func (m *SessionManager) Delete(id string) error {
+	if id == DefaultSessionID {
+		return errors.New("默认会话不能删除")
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.sessions[id]; !ok {
+		return fmt.Errorf("找不到会话 %s", id)
+	}
+	delete(m.sessions, id)
+	return nil
+}
+
+func newSessionID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("s%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
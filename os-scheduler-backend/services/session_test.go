@@ -0,0 +1,118 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSessionsAreIsolated(t *testing.T) {
+	m := NewSessionManager(0)
+	a, err := m.Create("a", DefaultConfig())
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	cfg := DefaultConfig()
+	cfg.ProcessorCount = 1
+	b, err := m.Create("b", cfg)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	addTestProcess(t, a.Scheduler, &models.PCB{Name: "p", RequiredTime: 3})
+	a.Scheduler.Schedule()
+	a.Scheduler.Schedule()
+	if info := a.Info(); info.Clock != 1 || info.Processes != 1 {
+		t.Errorf("session a: clock=%d processes=%d, want 1 and 1", info.Clock, info.Processes)
+	}
+	if info := b.Info(); info.Clock != 0 || info.Processes != 0 || info.Config.ProcessorCount != 1 {
+		t.Errorf("session b changed: %+v", info)
+	}
+}
+
+func TestSessionManagerRules(t *testing.T) {
+	m := NewSessionManager(2)
+	if _, err := m.Create("bad id", DefaultConfig()); err == nil {
+		t.Error("Create accepted an invalid session ID")
+	}
+	first, err := m.Create("", DefaultConfig())
+	if err != nil || first.ID == "" {
+		t.Fatalf("Create with an empty ID: %v", err)
+	}
+	if _, err := m.Create(first.ID, DefaultConfig()); err == nil {
+		t.Error("Create accepted a duplicate session ID")
+	}
+	if _, err := m.Create(DefaultSessionID, DefaultConfig()); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := m.Create("third", DefaultConfig()); err == nil || !strings.Contains(err.Error(), "上限") {
+		t.Errorf("Create error = %v, want a limit error", err)
+	}
+
+	if err := m.Delete(DefaultSessionID); err == nil {
+		t.Error("Delete removed the default session")
+	}
+	if err := m.Delete(first.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := m.Get(first.ID); ok {
+		t.Error("deleted session still found")
+	}
+	if sessions := m.List(); len(sessions) != 1 || sessions[0].ID != DefaultSessionID {
+		t.Errorf("List = %d sessions, want only the default session", len(sessions))
+	}
+}
+
+func TestConcurrentSessions(t *testing.T) {
+	m := NewSessionManager(0)
+	var wg sync.WaitGroup
+	for _, id := range []string{"a", "b", "c", "d"} {
+		session, err := m.Create(id, DefaultConfig())
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		s := session.Scheduler
+
+		// 每个会话一边调度一边被读取状态，读到的是锁内复制的副本
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if err := s.AddProcess(&models.PCB{Name: "p", RequiredTime: 2, MemorySize: 16}); err != nil {
+					t.Errorf("AddProcess: %v", err)
+					return
+				}
+				s.Schedule()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				state := s.State()
+				for _, p := range state.Queue.Running {
+					p.RequiredTime = -1
+				}
+				s.TimelineSlots()
+				s.TimelineEvents()
+				session.Info()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, session := range m.List() {
+		s := session.Scheduler
+		if n := len(s.allProcesses()); n != 50 {
+			t.Errorf("session %s has %d processes, want 50", session.ID, n)
+		}
+		if len(s.TimelineSlots()) != s.Clock {
+			t.Errorf("session %s: timeline has %d slots at clock %d", session.ID, len(s.TimelineSlots()), s.Clock)
+		}
+		for _, p := range s.allProcesses() {
+			if p.RequiredTime < 0 {
+				t.Fatalf("session %s: a copy returned by State changed process %d", session.ID, p.PID)
+			}
+		}
+	}
+}
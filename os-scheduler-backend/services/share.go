@@ -0,0 +1,174 @@
+package services
+
+import (
+	"fmt"
+	"os-scheduler-backend/models"
+	"sort"
+)
+
+// DefaultTickets 未指定彩票数的进程持有的彩票数
+const DefaultTickets = 100
+
+// strideConstant 步长调度中计算步长的常数，步长为 strideConstant/彩票数
+const strideConstant = 1 << 20
+
+// MaxTickets 进程最多持有的彩票数，保证步长至少为1，彩票总数也不会溢出
+const MaxTickets = strideConstant
+
+// tickets 返回进程持有的彩票数，未指定时为默认值
+func tickets(p *models.PCB) int {
+	if p.Tickets <= 0 {
+		return DefaultTickets
+	}
+	return p.Tickets
+}
+
+// stride 返回进程的步长，彩票越多步长越小
+func stride(p *models.PCB) int {
+	return strideConstant / tickets(p)
+}
+
+// SetTickets 设置进程的彩票数，0表示默认值，不能超过 MaxTickets，从下一次调度开始生效
+func (s *Scheduler) SetTickets(pid, count int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if count < 0 || count > MaxTickets {
+		return fmt.Errorf("彩票数必须在0到%d之间", MaxTickets)
+	}
+	p := s.findProcess(pid)
+	if p == nil {
+		return fmt.Errorf("找不到进程 %d", pid)
+	}
+	if p.State == models.Finished {
+		return fmt.Errorf("进程 %d 已完成", pid)
+	}
+	p.Tickets = count
+	s.eventLog.Record(OpSetTickets, ticketsArgs{PID: pid, Tickets: count})
+	return nil
+}
+
+// random 返回下一个伪随机数（splitmix64），状态保存在快照中，相同的种子得到相同的序列
+func (s *Scheduler) random() uint64 {
+	s.randomState += 0x9e3779b97f4a7c15
+	z := s.randomState
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// drawLottery 按彩票数不放回地依次抽签，先中签的进程排在就绪队列前面
+func (s *Scheduler) drawLottery() {
+	ready := s.Queue.Ready
+	total := 0
+	for _, p := range ready {
+		total += tickets(p)
+	}
+	for i := range ready {
+		n := int(s.random() % uint64(total))
+		for j := i; j < len(ready); j++ {
+			if n -= tickets(ready[j]); n < 0 {
+				ready[i], ready[j] = ready[j], ready[i]
+				break
+			}
+		}
+		total -= tickets(ready[i])
+	}
+}
+
+// liftPass 以上一个时钟周期运行过的进程运行前的最小行程值为基准，落后基准超过一个步长的就绪进程
+// （新到达或刚恢复的进程）从基准开始，不能凭不在就绪队列期间落后的行程值长期独占处理机
+func (s *Scheduler) liftPass() {
+	if len(s.Timeline) == 0 {
+		return
+	}
+	floor := -1
+	for _, pid := range s.Timeline[len(s.Timeline)-1].Processors {
+		if pid < 0 {
+			continue
+		}
+		if p := s.findProcess(pid); p != nil && (floor < 0 || p.Pass-stride(p) < floor) {
+			floor = p.Pass - stride(p)
+		}
+	}
+	if floor < 0 {
+		return
+	}
+	for _, p := range s.Queue.Ready {
+		if p.Pass < floor-stride(p) {
+			p.Pass = floor
+		}
+	}
+}
+
+// accrueEntitlement 把本时钟周期的处理机时间按彩票数分给就绪和运行中的进程，
+// 每个进程最多得到一个处理机，超出的部分分给其余进程
+func (s *Scheduler) accrueEntitlement() {
+	runnable := append(append([]*models.PCB(nil), s.Queue.Running...), s.Queue.Ready...)
+	capacity := float64(s.ProcessorCount)
+	for len(runnable) > 0 && capacity > 0 {
+		total := 0
+		for _, p := range runnable {
+			total += tickets(p)
+		}
+		rest := make([]*models.PCB, 0, len(runnable))
+		full := 0.0
+		for _, p := range runnable {
+			if capacity*float64(tickets(p))/float64(total) >= 1 {
+				p.Entitled++
+				full++
+			} else {
+				rest = append(rest, p)
+			}
+		}
+		if full == 0 {
+			for _, p := range rest {
+				p.Entitled += capacity * float64(tickets(p)) / float64(total)
+			}
+			return
+		}
+		capacity -= full
+		runnable = rest
+	}
+}
+
+// Shares 比较各进程实际占用的处理机时间与按彩票数应得的处理机时间
+func (s *Scheduler) Shares() *models.ShareReport {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	processes := s.allProcesses()
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].PID < processes[j].PID
+	})
+
+	report := &models.ShareReport{Clock: s.Clock, Processes: make([]models.ProcessShare, 0)}
+	cpuTotal, entitledTotal := 0, 0.0
+	for _, p := range processes {
+		if p.CPUTime == 0 && p.Entitled == 0 {
+			continue
+		}
+		report.Processes = append(report.Processes, models.ProcessShare{
+			PID:      p.PID,
+			Name:     p.Name,
+			Tickets:  tickets(p),
+			CPUTime:  p.CPUTime,
+			Entitled: p.Entitled,
+		})
+		cpuTotal += p.CPUTime
+		entitledTotal += p.Entitled
+	}
+	for i := range report.Processes {
+		ps := &report.Processes[i]
+		if cpuTotal > 0 {
+			ps.AchievedShare = float64(ps.CPUTime) / float64(cpuTotal)
+		}
+		if entitledTotal > 0 {
+			ps.ExpectedShare = ps.Entitled / entitledTotal
+		}
+		if ps.Entitled > 0 {
+			ps.Ratio = float64(ps.CPUTime) / ps.Entitled
+		}
+	}
+	return report
+}
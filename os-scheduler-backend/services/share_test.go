@@ -0,0 +1,104 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"strings"
+	"testing"
+)
+
+// shareSystem 创建单处理机、按指定策略调度的系统，并添加持有各彩票数的长作业
+func shareSystem(t *testing.T, policy models.SchedulingPolicy, tickets ...int) (*Scheduler, []int) {
+	t.Helper()
+	s := newTestSystem(t, func(cfg *models.SystemConfig) {
+		cfg.ProcessorCount = 1
+		cfg.Policy = policy
+		cfg.Seed = 42
+	})
+	pids := make([]int, 0, len(tickets))
+	for _, n := range tickets {
+		pids = append(pids, addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 100000, Tickets: n}))
+	}
+	return s, pids
+}
+
+func TestStrideSharesFollowTickets(t *testing.T) {
+	s, pids := shareSystem(t, models.PolicyStride, 300, 200, 100)
+	for i := 0; i < 601; i++ {
+		s.Schedule()
+	}
+	// 步长调度是确定性的，每个进程与应得时间的偏差不超过一个时钟周期
+	for i, want := range []int{300, 200, 100} {
+		if got := s.findProcess(pids[i]).CPUTime; got < want-1 || got > want+1 {
+			t.Errorf("process with %d tickets ran %d ticks, want %d", want, got, want)
+		}
+	}
+}
+
+func TestLotterySharesFollowTickets(t *testing.T) {
+	s, pids := shareSystem(t, models.PolicyLottery, 300, 100)
+	for i := 0; i < 1001; i++ {
+		s.Schedule()
+	}
+	share := float64(s.findProcess(pids[0]).CPUTime) / 1000
+	if share < 0.7 || share > 0.8 {
+		t.Errorf("process with 3/4 of the tickets got %.3f of the processor", share)
+	}
+
+	// 相同的种子得到相同的抽签结果
+	again, _ := shareSystem(t, models.PolicyLottery, 300, 100)
+	for i := 0; i < 1001; i++ {
+		again.Schedule()
+	}
+	if got, want := again.findProcess(pids[0]).CPUTime, s.findProcess(pids[0]).CPUTime; got != want {
+		t.Errorf("same seed ran %d ticks, want %d", got, want)
+	}
+
+	report := s.Shares()
+	if len(report.Processes) != 2 {
+		t.Fatalf("share report has %d processes, want 2", len(report.Processes))
+	}
+	if expected := report.Processes[0].ExpectedShare; expected < 0.749 || expected > 0.751 {
+		t.Errorf("expected share = %.3f, want 0.75", expected)
+	}
+}
+
+func TestTicketsAreBounded(t *testing.T) {
+	s := newTestSystem(t, nil)
+	if err := s.AddProcess(&models.PCB{Name: "rich", RequiredTime: 2, Tickets: MaxTickets + 1}); err == nil {
+		t.Error("AddProcess accepted more than MaxTickets tickets")
+	}
+	if err := s.AddProcess(&models.PCB{Name: "debt", RequiredTime: 2, Tickets: -1}); err == nil {
+		t.Error("AddProcess accepted negative tickets")
+	}
+	_, _, err := s.AddProcessBatch([]models.BatchProcess{{ID: "rich", Name: "rich", RequiredTime: 2, Tickets: MaxTickets + 1}})
+	if err == nil || !strings.Contains(err.Error(), "彩票数") {
+		t.Errorf("AddProcessBatch error = %v, want a tickets error", err)
+	}
+	pid := addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 2})
+	if err := s.SetTickets(pid, MaxTickets+1); err == nil {
+		t.Error("SetTickets accepted more than MaxTickets tickets")
+	}
+	if n := len(s.allProcesses()); n != 1 {
+		t.Errorf("%d processes after rejected submissions, want 1", n)
+	}
+}
+
+func TestMaxTicketsKeepStrideAndLotteryWorking(t *testing.T) {
+	for _, policy := range []models.SchedulingPolicy{models.PolicyLottery, models.PolicyStride, models.PolicyFairShare} {
+		t.Run(string(policy), func(t *testing.T) {
+			s, pids := shareSystem(t, policy, MaxTickets, MaxTickets, MaxTickets, MaxTickets)
+			for i := 0; i < 41; i++ {
+				s.Schedule()
+			}
+			for _, pid := range pids {
+				p := s.findProcess(pid)
+				if stride(p) < 1 {
+					t.Fatalf("stride = %d, want at least 1", stride(p))
+				}
+				if p.CPUTime < 9 || p.CPUTime > 11 {
+					t.Errorf("process %d ran %d of 40 ticks, want about 10", pid, p.CPUTime)
+				}
+			}
+		})
+	}
+}
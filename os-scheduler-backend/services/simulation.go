@@ -0,0 +1,224 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os-scheduler-backend/models"
+)
+
+// DefaultMaxTicks 模拟的默认最大时钟周期数
+const DefaultMaxTicks = 100000
+
+// DefaultConfig 返回默认系统参数：2个处理机、道数8、内存4096（操作系统占256）
+func DefaultConfig() models.SystemConfig {
+	return models.SystemConfig{
+		ProcessorCount:  2,
+		MaxProcesses:    8,
+		TotalMemory:     4096,
+		OSMemory:        256,
+		Policy:          models.PolicyPriority,
+		TimeQuantum:     DefaultTimeQuantum,
+		Allocator:       models.FirstFit,
+		BalanceInterval: DefaultBalanceInterval,
+		TargetLatency:   DefaultTargetLatency,
+		MinGranularity:  DefaultMinGranularity,
+		AgingStep:       DefaultAgingStep,
+		LockProtocol:    models.LockNone,
+	}
+}
+
+// ValidateConfig 校验系统参数
+func ValidateConfig(cfg models.SystemConfig) error {
+	switch {
+	case cfg.ProcessorCount <= 0:
+		return errors.New("处理机数量必须大于0")
+	case !validProcessors(cfg.Processors):
+		return fmt.Errorf("处理机速度必须在%g到%g之间", MinProcessorSpeed, MaxProcessorSpeed)
+	case cfg.MaxProcesses <= 0:
+		return errors.New("道数必须大于0")
+	case cfg.OSMemory < 0 || cfg.TotalMemory <= cfg.OSMemory:
+		return errors.New("内存总大小必须大于操作系统占用的内存")
+	case !ValidPolicy(cfg.Policy):
+		return fmt.Errorf("不支持的调度策略 %s", cfg.Policy)
+	case cfg.TimeQuantum <= 0:
+		return errors.New("时间片长度必须大于0")
+	case !ValidAllocator(cfg.Allocator):
+		return fmt.Errorf("不支持的内存分配算法 %s", cfg.Allocator)
+	case cfg.MigrationCost < 0:
+		return errors.New("迁移开销不能为负数")
+	case cfg.BalanceInterval < 0:
+		return errors.New("负载均衡间隔不能为负数")
+	case cfg.MinGranularity <= 0:
+		return errors.New("最短运行时间必须大于0")
+	case cfg.TargetLatency < cfg.MinGranularity:
+		return errors.New("目标延迟不能小于最短运行时间")
+	case cfg.AgingInterval < 0:
+		return errors.New("老化间隔不能为负数")
+	case cfg.AgingStep < 0:
+		return errors.New("老化步长不能为负数")
+	case !ValidLockProtocol(cfg.LockProtocol):
+		return fmt.Errorf("不支持的锁协议 %s", cfg.LockProtocol)
+	case cfg.ContextSwitchCost < 0:
+		return errors.New("上下文切换开销不能为负数")
+	}
+	return validGroupShares(cfg.GroupShares)
+}
+
+// NewSystem 按系统参数创建调度器及其内存管理器
+func NewSystem(cfg models.SystemConfig) (*Scheduler, error) {
+	if err := ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	mm := NewMemoryManager(cfg.TotalMemory, cfg.OSMemory)
+	mm.Memory.Algorithm = cfg.Allocator
+	s := NewScheduler(cfg.ProcessorCount, cfg.MaxProcesses, mm)
+	s.Processors = normalizeProcessors(cfg.Processors, cfg.ProcessorCount)
+	s.Policy = cfg.Policy
+	s.TimeQuantum = cfg.TimeQuantum
+	s.PerProcessorQueues = cfg.PerProcessorQueues
+	s.MigrationCost = cfg.MigrationCost
+	s.BalanceInterval = cfg.BalanceInterval
+	s.Seed = cfg.Seed
+	s.randomState = cfg.Seed
+	s.TargetLatency = cfg.TargetLatency
+	s.MinGranularity = cfg.MinGranularity
+	s.AgingInterval = cfg.AgingInterval
+	s.AgingStep = cfg.AgingStep
+	s.AgeBackup = cfg.AgeBackup
+	s.LockProtocol = cfg.LockProtocol
+	s.ContextSwitchCost = cfg.ContextSwitchCost
+	s.GroupShares = cloneShares(cfg.GroupShares)
+	return s, nil
+}
+
+// Config 返回当前的系统参数
+func (s *Scheduler) Config() models.SystemConfig {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.config()
+}
+
+func (s *Scheduler) config() models.SystemConfig {
+	return models.SystemConfig{
+		ProcessorCount:     s.ProcessorCount,
+		Processors:         append([]models.ProcessorSpec(nil), s.Processors...),
+		MaxProcesses:       s.MaxProcesses,
+		TotalMemory:        s.memoryManager.Memory.TotalSize,
+		OSMemory:           s.memoryManager.Memory.OSSize,
+		Policy:             s.Policy,
+		TimeQuantum:        s.TimeQuantum,
+		Allocator:          s.memoryManager.Memory.Algorithm,
+		PerProcessorQueues: s.PerProcessorQueues,
+		MigrationCost:      s.MigrationCost,
+		BalanceInterval:    s.BalanceInterval,
+		Seed:               s.Seed,
+		TargetLatency:      s.TargetLatency,
+		MinGranularity:     s.MinGranularity,
+		AgingInterval:      s.AgingInterval,
+		AgingStep:          s.AgingStep,
+		AgeBackup:          s.AgeBackup,
+		LockProtocol:       s.LockProtocol,
+		ContextSwitchCost:  s.ContextSwitchCost,
+		GroupShares:        cloneShares(s.GroupShares),
+	}
+}
+
+// MemoryManager 返回调度器使用的内存管理器
+func (s *Scheduler) MemoryManager() *MemoryManager {
+	return s.memoryManager
+}
+
+// unfinished 返回尚未完成的进程数
+func (s *Scheduler) unfinished() int {
+	q := s.Queue
+	return len(q.New) + len(q.Ready) + len(q.Running) + len(q.Waiting) + len(q.Backup) + len(q.Suspended)
+}
+
+// stalled 在一次调度之后判断系统是否再也无法推进：没有进程在运行，以后不会再有新进程到达或周期作业释放，
+// 就绪队列中没有能分配到处理机的进程，后备队列中的进程也调不进来（进程组还有进程未就绪时不能调入）。
+// 此时没有进程会完成或释放锁，已到达的新进程分配不到内存，等待和挂起的进程也不会再就绪
+func (s *Scheduler) stalled() bool {
+	q := s.Queue
+	if len(q.Running) > 0 {
+		return false
+	}
+	for _, task := range s.Tasks {
+		if !task.Sporadic {
+			return false
+		}
+	}
+	for _, p := range q.New {
+		if p.ArrivalTime > s.Clock {
+			return false
+		}
+	}
+	gangs := s.gangPlacements()
+	for _, p := range q.Backup {
+		if len(q.Ready) < s.MaxProcesses && (p.Gang == "" || gangs[p.Gang].pending == 0) {
+			return false
+		}
+	}
+	for _, p := range q.Ready {
+		if s.dispatchable(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// dispatchable 判断处理机都空闲时就绪进程能否分配到处理机：亲和性须允许某个现有处理机，
+// 属于进程组时组内尚未完成的进程都须已就绪
+func (s *Scheduler) dispatchable(p *models.PCB) bool {
+	if !s.anyProcessorAllowed(p.AffinityMask) {
+		return false
+	}
+	if p.Gang == "" {
+		return true
+	}
+	for _, member := range s.gangMembers(p.Gang) {
+		if member.State != models.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// RunWorkload 在按cfg新建的调度器上运行负载直到所有进程完成，
+// 超过maxTicks个时钟周期仍未完成或系统无法继续推进时返回错误
+func RunWorkload(workload *models.Workload, cfg models.SystemConfig, maxTicks int) (*models.SimulationResult, error) {
+	s, err := NewSystem(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// 模拟不需要回退，关闭历史快照以节省内存
+	s.SetHistorySize(0)
+
+	mapping, err := s.SubmitWorkload(workload)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		s.Schedule()
+		if s.unfinished() == 0 {
+			break
+		}
+		if s.stalled() {
+			return nil, fmt.Errorf("模拟在时刻 %d 无法继续：没有进程能够运行或调入", s.Clock)
+		}
+		if s.Clock >= maxTicks {
+			return nil, fmt.Errorf("模拟超过 %d 个时钟周期仍未完成", maxTicks)
+		}
+	}
+
+	return &models.SimulationResult{
+		Workload: workload.Name,
+		Config:   s.Config(),
+		Mapping:  mapping,
+		Stats:    s.Stats(),
+		Timeline: s.Timeline,
+		Events:   s.Events,
+	}, nil
+}
@@ -0,0 +1,376 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os-scheduler-backend/models"
+	"path/filepath"
+)
+
+// Snapshot 获取调度器与内存管理器当前状态的深拷贝
+func (s *Scheduler) Snapshot() *models.Snapshot {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.snapshot()
+}
+
+// State 获取除时间线和时间线事件以外的状态的深拷贝，供接口在锁外读取
+func (s *Scheduler) State() *models.Snapshot {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.stateSnapshot()
+}
+
+// TimelineSlots 获取时间线的深拷贝
+func (s *Scheduler) TimelineSlots() []models.TimelineSlot {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return models.CloneTimeline(s.Timeline)
+}
+
+// TimelineEvents 获取时间线事件的深拷贝
+func (s *Scheduler) TimelineEvents() []models.TimelineEvent {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return models.CloneEvents(s.Events)
+}
+
+func (s *Scheduler) snapshot() *models.Snapshot {
+	snap := s.stateSnapshot()
+	snap.Timeline = models.CloneTimeline(s.Timeline)
+	snap.Events = models.CloneEvents(s.Events)
+	return snap
+}
+
+// stateSnapshot 获取除时间线和时间线事件以外的状态的深拷贝
+func (s *Scheduler) stateSnapshot() *models.Snapshot {
+	return &models.Snapshot{
+		Version:            models.SnapshotVersion,
+		Clock:              s.Clock,
+		NextPID:            s.nextPID,
+		ProcessorCount:     s.ProcessorCount,
+		Processors:         append([]models.ProcessorSpec(nil), s.Processors...),
+		MaxProcesses:       s.MaxProcesses,
+		Policy:             s.Policy,
+		TimeQuantum:        s.TimeQuantum,
+		PerProcessorQueues: s.PerProcessorQueues,
+		MigrationCost:      s.MigrationCost,
+		BalanceInterval:    s.BalanceInterval,
+		Seed:               s.Seed,
+		RandomState:        s.randomState,
+		TargetLatency:      s.TargetLatency,
+		MinGranularity:     s.MinGranularity,
+		MinVRuntime:        s.MinVRuntime,
+		AgingInterval:      s.AgingInterval,
+		AgingStep:          s.AgingStep,
+		AgeBackup:          s.AgeBackup,
+		LockProtocol:       s.LockProtocol,
+		ContextSwitchCost:  s.ContextSwitchCost,
+		GroupShares:        cloneShares(s.GroupShares),
+		Started:            s.started,
+		Tasks:              models.CloneTasks(s.Tasks),
+		NextTaskID:         s.nextTaskID,
+		Queue:              s.Queue.Clone(),
+		Memory:             s.memoryManager.Memory.Clone(),
+	}
+}
+
+// Restore 用快照替换调度器与内存管理器的状态，二者的实例保持不变，回退历史被清空
+func (s *Scheduler) Restore(snap *models.Snapshot) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.restore(snap); err != nil {
+		return err
+	}
+	s.history.clear()
+	s.eventLog.Record(OpRestore, restoreArgs{Snapshot: snap})
+	return nil
+}
+
+// restore 先校验整个快照，校验失败时不修改任何状态
+func (s *Scheduler) restore(snap *models.Snapshot) error {
+	if err := validateSnapshot(snap); err != nil {
+		return err
+	}
+
+	// 以下只修改状态，不再失败
+	cfg := snapshotConfig(snap)
+	s.Clock = snap.Clock
+	s.nextPID = snap.NextPID
+	s.ProcessorCount = cfg.ProcessorCount
+	s.Processors = normalizeProcessors(cfg.Processors, cfg.ProcessorCount)
+	s.MaxProcesses = cfg.MaxProcesses
+	s.Policy = cfg.Policy
+	s.TimeQuantum = cfg.TimeQuantum
+	s.PerProcessorQueues = cfg.PerProcessorQueues
+	s.MigrationCost = cfg.MigrationCost
+	s.BalanceInterval = cfg.BalanceInterval
+	s.Seed = cfg.Seed
+	s.randomState = snap.RandomState
+	s.TargetLatency = cfg.TargetLatency
+	s.MinGranularity = cfg.MinGranularity
+	s.MinVRuntime = snap.MinVRuntime
+	s.AgingInterval = cfg.AgingInterval
+	s.AgingStep = cfg.AgingStep
+	s.AgeBackup = cfg.AgeBackup
+	s.LockProtocol = cfg.LockProtocol
+	s.ContextSwitchCost = cfg.ContextSwitchCost
+	s.GroupShares = cloneShares(cfg.GroupShares)
+	s.cfs = nil
+	s.loaded = nil
+	s.started = snap.Started
+	s.Timeline = models.CloneTimeline(snap.Timeline)
+	s.Events = models.CloneEvents(snap.Events)
+	s.Tasks = models.CloneTasks(snap.Tasks)
+	s.nextTaskID = snap.NextTaskID
+	if s.nextTaskID <= 0 {
+		s.nextTaskID = 1
+	}
+	s.Queue = snap.Queue.Clone()
+	// 较早的快照只有一种挂起状态，挂起的进程都来自就绪或运行状态
+	for _, p := range s.Queue.Suspended {
+		if p.State == "suspended" {
+			p.State = models.SuspendedReady
+		}
+	}
+	s.memoryManager.Memory = snap.Memory.Clone()
+	return nil
+}
+
+// snapshotConfig 返回快照中的系统参数，较早的快照中缺少的参数取默认值
+func snapshotConfig(snap *models.Snapshot) models.SystemConfig {
+	cfg := models.SystemConfig{
+		ProcessorCount:     snap.ProcessorCount,
+		Processors:         snap.Processors,
+		MaxProcesses:       snap.MaxProcesses,
+		TotalMemory:        snap.Memory.TotalSize,
+		OSMemory:           snap.Memory.OSSize,
+		Policy:             snap.Policy,
+		TimeQuantum:        snap.TimeQuantum,
+		Allocator:          snap.Memory.Algorithm,
+		PerProcessorQueues: snap.PerProcessorQueues,
+		MigrationCost:      snap.MigrationCost,
+		BalanceInterval:    snap.BalanceInterval,
+		Seed:               snap.Seed,
+		TargetLatency:      snap.TargetLatency,
+		MinGranularity:     snap.MinGranularity,
+		AgingInterval:      snap.AgingInterval,
+		AgingStep:          snap.AgingStep,
+		AgeBackup:          snap.AgeBackup,
+		LockProtocol:       snap.LockProtocol,
+		ContextSwitchCost:  snap.ContextSwitchCost,
+		GroupShares:        snap.GroupShares,
+	}
+	// 较早的快照没有完全公平调度的参数和锁协议
+	if cfg.MinGranularity <= 0 || cfg.TargetLatency < cfg.MinGranularity {
+		cfg.TargetLatency = DefaultTargetLatency
+		cfg.MinGranularity = DefaultMinGranularity
+	}
+	if cfg.LockProtocol == "" {
+		cfg.LockProtocol = models.LockNone
+	}
+	return cfg
+}
+
+// validateSnapshot 校验快照中的系统参数、内存分区、各队列中的进程和前驱图，
+// 保证恢复后的状态与调度器自己产生的状态一样一致
+func validateSnapshot(snap *models.Snapshot) error {
+	if snap == nil || snap.Queue == nil || snap.Memory == nil {
+		return errors.New("快照内容不完整")
+	}
+	if snap.Version != models.SnapshotVersion {
+		return errors.New("不支持的快照版本")
+	}
+	cfg := snapshotConfig(snap)
+	if err := ValidateConfig(cfg); err != nil {
+		return fmt.Errorf("快照中的系统参数无效: %w", err)
+	}
+	if snap.Clock < 0 || snap.NextPID <= 0 {
+		return errors.New("快照中的时钟或下一个PID无效")
+	}
+	if err := validateSnapshotMemory(snap.Memory); err != nil {
+		return err
+	}
+	if err := validateSnapshotQueues(snap, cfg.ProcessorCount); err != nil {
+		return err
+	}
+	return validateSnapshotTasks(snap)
+}
+
+// validateSnapshotMemory 校验内存分区从操作系统区之后开始，首尾相接地覆盖到内存末尾
+func validateSnapshotMemory(memory *models.MemoryManager) error {
+	end := memory.OSSize
+	for _, block := range memory.Blocks {
+		if block.Start != end || block.Length <= 0 {
+			return fmt.Errorf("快照中起始地址为 %d 的内存分区与前一个分区不相接或长度无效", block.Start)
+		}
+		end += block.Length
+	}
+	if end != memory.TotalSize {
+		return errors.New("快照中的内存分区没有覆盖整个用户区")
+	}
+	return nil
+}
+
+// validateSnapshotQueues 校验各队列中进程的状态与所在队列一致，PID不重复且小于下一个PID，
+// 运行中的进程各占一个现有处理机，占用内存的进程对应已分配的内存分区，前驱图完整且无环
+func validateSnapshotQueues(snap *models.Snapshot, processorCount int) error {
+	q := snap.Queue
+	queues := []struct {
+		name      string
+		processes []*models.PCB
+		states    []models.ProcessState
+	}{
+		{"new", q.New, []models.ProcessState{models.New}},
+		{"ready", q.Ready, []models.ProcessState{models.Ready}},
+		{"running", q.Running, []models.ProcessState{models.Running}},
+		{"waiting", q.Waiting, []models.ProcessState{models.Waiting}},
+		{"backup", q.Backup, []models.ProcessState{models.Ready}},
+		{"suspended", q.Suspended, []models.ProcessState{models.SuspendedReady, models.SuspendedBlocked, "suspended"}},
+		{"finished", q.Finished, []models.ProcessState{models.Finished}},
+	}
+	used := make(map[int]bool)
+	for _, block := range snap.Memory.Blocks {
+		if block.IsUsed {
+			used[block.Start] = true
+		}
+	}
+
+	seen := make(map[int]bool)
+	cpus := make(map[int]bool)
+	processes := make([]*models.PCB, 0)
+	for _, queue := range queues {
+		for _, p := range queue.processes {
+			if p == nil {
+				return fmt.Errorf("快照的 %s 队列中有空进程", queue.name)
+			}
+			if p.PID <= 0 || p.PID >= snap.NextPID || seen[p.PID] {
+				return fmt.Errorf("快照中进程 %d 的PID重复或不小于下一个PID %d", p.PID, snap.NextPID)
+			}
+			seen[p.PID] = true
+			if !containsState(queue.states, p.State) {
+				return fmt.Errorf("快照中进程 %d 的状态 %q 与所在的 %s 队列不一致", p.PID, p.State, queue.name)
+			}
+			if p.RequiredTime < 0 || p.RequiredTime > p.TotalRequiredTime {
+				return fmt.Errorf("快照中进程 %d 的剩余运行时间无效", p.PID)
+			}
+			if p.Tickets < 0 || p.Tickets > MaxTickets {
+				return fmt.Errorf("快照中进程 %d 的彩票数无效", p.PID)
+			}
+			if p.State == models.Running {
+				if p.ProcessorID < 0 || p.ProcessorID >= processorCount || cpus[p.ProcessorID] {
+					return fmt.Errorf("快照中运行的进程 %d 所在的处理机 %d 无效或被占用", p.PID, p.ProcessorID)
+				}
+				cpus[p.ProcessorID] = true
+			}
+			holdsMemory := p.State != models.New && p.State != models.Finished
+			if holdsMemory && p.MemorySize > 0 && !used[p.MemoryStart] {
+				return fmt.Errorf("快照中进程 %d 的内存起始地址 %d 不是已分配的内存分区", p.PID, p.MemoryStart)
+			}
+			processes = append(processes, p)
+		}
+	}
+
+	// 所有进程都视为新提交的进程，校验前驱都存在且整个前驱图无环
+	empty := &Scheduler{Queue: newProcessQueue()}
+	if err := empty.validatePrecedence(processes); err != nil {
+		return fmt.Errorf("快照中的前驱关系无效: %w", err)
+	}
+	return nil
+}
+
+// validateSnapshotTasks 校验快照中的实时任务
+func validateSnapshotTasks(snap *models.Snapshot) error {
+	ids := make(map[int]bool, len(snap.Tasks))
+	for _, task := range snap.Tasks {
+		if task == nil || task.ID <= 0 || ids[task.ID] || (snap.NextTaskID > 0 && task.ID >= snap.NextTaskID) {
+			return errors.New("快照中的实时任务ID重复或无效")
+		}
+		ids[task.ID] = true
+		if task.Period <= 0 || task.WCET <= 0 || task.Deadline <= 0 || task.Deadline > task.Period {
+			return fmt.Errorf("快照中实时任务 %d 的参数无效", task.ID)
+		}
+	}
+	return nil
+}
+
+// containsState 判断状态是否在列表中
+func containsState(states []models.ProcessState, state models.ProcessState) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveSnapshot 将当前状态以JSON格式写入文件
+func (s *Scheduler) SaveSnapshot(path string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.saveSnapshot(path)
+}
+
+func (s *Scheduler) saveSnapshot(path string) error {
+	data, err := json.MarshalIndent(s.snapshot(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// 先写临时文件再重命名，避免写入中途失败时破坏原有快照
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadSnapshot 从JSON文件恢复状态
+func (s *Scheduler) LoadSnapshot(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var snap models.Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return err
+	}
+	return s.Restore(&snap)
+}
+
+// EnableAutosave 每执行every次调度自动保存一次快照，every<=0时关闭自动保存
+func (s *Scheduler) EnableAutosave(path string, every int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.autosavePath = path
+	s.autosaveEvery = every
+}
+
+// autosave 在调度后按配置的间隔保存快照
+func (s *Scheduler) autosave() {
+	if s.autosaveEvery <= 0 || s.Clock%s.autosaveEvery != 0 {
+		return
+	}
+	if err := s.saveSnapshot(s.autosavePath); err != nil {
+		log.Printf("自动保存快照失败: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"encoding/json"
+	"os-scheduler-backend/models"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// snapshotJSON 返回调度器当前快照的JSON编码，用于比较两个状态是否相同
+func snapshotJSON(t *testing.T, s *Scheduler) string {
+	t.Helper()
+	data, err := json.Marshal(s.Snapshot())
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	return string(data)
+}
+
+// snapshotFixture 返回运行了几个时钟周期、有前驱关系和等待进程的调度器
+func snapshotFixture(t *testing.T) *Scheduler {
+	t.Helper()
+	s := newTestSystem(t, nil)
+	a := addTestProcess(t, s, &models.PCB{Name: "a", RequiredTime: 3, Priority: 5, MemorySize: 40})
+	b := addTestProcess(t, s, &models.PCB{Name: "b", RequiredTime: 2, Priority: 3, MemorySize: 20})
+	addTestProcess(t, s, &models.PCB{Name: "c", RequiredTime: 2, MemorySize: 30, Predecessors: []int{a, b}})
+	for i := 0; i < 2; i++ {
+		s.Schedule()
+	}
+	return s
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	s := snapshotFixture(t)
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := s.SaveSnapshot(path); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+
+	restored := newTestSystem(t, nil)
+	if err := restored.LoadSnapshot(path); err != nil {
+		t.Fatalf("LoadSnapshot: %v", err)
+	}
+	if got, want := snapshotJSON(t, restored), snapshotJSON(t, s); got != want {
+		t.Fatalf("restored state differs\ngot:  %s\nwant: %s", got, want)
+	}
+
+	// 恢复后的调度器与原调度器继续运行的结果相同
+	for i := 0; i < 10; i++ {
+		s.Schedule()
+		restored.Schedule()
+	}
+	if got, want := snapshotJSON(t, restored), snapshotJSON(t, s); got != want {
+		t.Fatalf("restored scheduler diverged\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestRestoreRejectsInvalidSnapshots(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(snap *models.Snapshot)
+		want   string
+	}{
+		{"missing queue", func(snap *models.Snapshot) { snap.Queue = nil }, "不完整"},
+		{"version", func(snap *models.Snapshot) { snap.Version++ }, "版本"},
+		{"negative processors", func(snap *models.Snapshot) { snap.ProcessorCount = -1 }, "处理机数量"},
+		{"no processors", func(snap *models.Snapshot) { snap.ProcessorCount = 0 }, "处理机数量"},
+		{"no slots", func(snap *models.Snapshot) { snap.MaxProcesses = 0 }, "道数"},
+		{"policy", func(snap *models.Snapshot) { snap.Policy = "random" }, "调度策略"},
+		{"quantum", func(snap *models.Snapshot) { snap.TimeQuantum = 0 }, "时间片"},
+		{"next pid", func(snap *models.Snapshot) { snap.NextPID = 0 }, "下一个PID"},
+		{"memory gap", func(snap *models.Snapshot) { snap.Memory.Blocks[0].Start++ }, "内存分区"},
+		{"memory short", func(snap *models.Snapshot) { snap.Memory.TotalSize++ }, "内存分区"},
+		{"state mismatch", func(snap *models.Snapshot) { snap.Queue.Waiting[0].State = models.Ready }, "不一致"},
+		{"duplicate pid", func(snap *models.Snapshot) { snap.Queue.Waiting[0].PID = snap.Queue.Running[0].PID }, "PID"},
+		{"pid too high", func(snap *models.Snapshot) { snap.Queue.Waiting[0].PID = snap.NextPID }, "PID"},
+		{"processor", func(snap *models.Snapshot) { snap.Queue.Running[0].ProcessorID = snap.ProcessorCount }, "处理机"},
+		{"memory start", func(snap *models.Snapshot) { snap.Queue.Waiting[0].MemoryStart++ }, "内存起始地址"},
+		{"unknown predecessor", func(snap *models.Snapshot) {
+			snap.Queue.Waiting[0].Predecessors = append(snap.Queue.Waiting[0].Predecessors, 99)
+		}, "前驱"},
+		{"cycle", func(snap *models.Snapshot) {
+			for _, p := range snap.Queue.Running {
+				p.Predecessors = []int{snap.Queue.Waiting[0].PID}
+			}
+		}, "环"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := snapshotFixture(t)
+			before := snapshotJSON(t, s)
+			snap := s.Snapshot()
+			tc.modify(snap)
+			err := s.Restore(snap)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("Restore error = %v, want %q", err, tc.want)
+			}
+			if after := snapshotJSON(t, s); after != before {
+				t.Errorf("rejected restore changed the state\nbefore: %s\nafter:  %s", before, after)
+			}
+		})
+	}
+}
+
+func TestRestoreRejectsBadSharesWithoutChanges(t *testing.T) {
+	s := snapshotFixture(t)
+	before := snapshotJSON(t, s)
+
+	bad := s.Snapshot()
+	bad.Clock = 99
+	bad.Policy = models.PolicyFairShare
+	bad.GroupShares = map[string]int{"lab": 0}
+	if err := s.Restore(bad); err == nil {
+		t.Fatal("Restore accepted a snapshot with invalid group shares")
+	}
+	if after := snapshotJSON(t, s); after != before {
+		t.Errorf("rejected restore changed the state\nbefore: %s\nafter:  %s", before, after)
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"strings"
+	"testing"
+)
+
+func TestSpeedScalesProgress(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) {
+		cfg.ProcessorCount = 2
+		cfg.Processors = []models.ProcessorSpec{{Speed: 2}, {Speed: 0.5}}
+	})
+	fast := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "fast", RequiredTime: 6, Priority: 2}))
+	slow := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "slow", RequiredTime: 6, Priority: 1}))
+
+	// 优先数大的进程获得快的处理机，速度0.5的处理机每两个周期推进1
+	s.Schedule()
+	if fast.ProcessorID != 0 || slow.ProcessorID != 1 {
+		t.Fatalf("processors = %d/%d, want the faster one for the higher priority", fast.ProcessorID, slow.ProcessorID)
+	}
+	for i := 0; i < 3; i++ {
+		s.Schedule()
+	}
+	if fast.State != models.Finished || fast.CPUTime != 3 {
+		t.Errorf("fast process: state=%s cpuTime=%d, want finished after 3 ticks", fast.State, fast.CPUTime)
+	}
+	if got := progress(slow); got != 1 {
+		t.Errorf("slow process progress = %d after 3 ticks, want 1", got)
+	}
+
+	stats := s.Stats()
+	if stats.PerProcessor[0].Speed != 2 || stats.PerProcessor[1].Speed != 0.5 {
+		t.Errorf("per processor speeds = %v/%v", stats.PerProcessor[0].Speed, stats.PerProcessor[1].Speed)
+	}
+}
+
+func TestProcessesGetFastestAllowedProcessor(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) {
+		cfg.ProcessorCount = 3
+		cfg.Processors = []models.ProcessorSpec{{Speed: 1}, {Speed: 4}, {}}
+	})
+	p := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 8}))
+	pinned := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "pinned", RequiredTime: 8, AffinityMask: 1 << 2}))
+	s.Schedule()
+	if p.ProcessorID != 1 || pinned.ProcessorID != 2 {
+		t.Errorf("processors = %d/%d, want 1 and 2", p.ProcessorID, pinned.ProcessorID)
+	}
+	if got := s.Processors[2].Speed; got != 1 {
+		t.Errorf("unspecified speed = %v, want 1", got)
+	}
+}
+
+func TestProcessorSpeedRange(t *testing.T) {
+	for _, speed := range []float64{-1, MinProcessorSpeed / 2, MaxProcessorSpeed * 2} {
+		cfg := DefaultConfig()
+		cfg.Processors = []models.ProcessorSpec{{Speed: speed}}
+		if err := ValidateConfig(cfg); err == nil || !strings.Contains(err.Error(), "处理机速度") {
+			t.Errorf("speed %v: ValidateConfig error = %v, want a speed error", speed, err)
+		}
+	}
+	for _, speed := range []float64{0, MinProcessorSpeed, MaxProcessorSpeed} {
+		cfg := DefaultConfig()
+		cfg.Processors = []models.ProcessorSpec{{Speed: speed}}
+		if err := ValidateConfig(cfg); err != nil {
+			t.Errorf("speed %v: ValidateConfig: %v", speed, err)
+		}
+	}
+
+	for _, list := range []string{"2,0", "1,NaN", "1,-2", "5000"} {
+		if _, err := parseSpeeds(list); err == nil {
+			t.Errorf("parseSpeeds(%q) accepted an invalid speed", list)
+		}
+	}
+	specs, err := parseSpeeds("2, 0.5")
+	if err != nil || len(specs) != 2 || specs[1].Speed != 0.5 {
+		t.Errorf("parseSpeeds = %v, %v", specs, err)
+	}
+}
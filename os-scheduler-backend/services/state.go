@@ -0,0 +1,53 @@
+package services
+
+import (
+	"fmt"
+	"os-scheduler-backend/models"
+)
+
+// transitions 合法的进程状态转换（七状态模型），空状态表示刚创建、尚未进入任何队列的进程。
+// 后备队列中的进程处于就绪状态，在后备队列和就绪队列之间移动不是状态转换。
+// 挂起阻塞的进程等待的事件发生后转为挂起就绪，恢复时直接回到就绪队列
+var transitions = map[models.ProcessState][]models.ProcessState{
+	"":                      {models.New, models.Ready, models.Waiting},
+	models.New:              {models.Ready, models.Waiting},
+	models.Ready:            {models.Running, models.SuspendedReady},
+	models.Running:          {models.Ready, models.Waiting, models.SuspendedReady, models.Finished},
+	models.Waiting:          {models.Ready, models.SuspendedBlocked},
+	models.SuspendedReady:   {models.Ready},
+	models.SuspendedBlocked: {models.Waiting, models.SuspendedReady},
+}
+
+// ValidTransition 判断进程能否从状态from转换到状态to
+func ValidTransition(from, to models.ProcessState) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transition 把进程转换到新状态，并在进程的状态历史中记录生效的时钟周期和原因。
+// 进程状态只通过这里修改；转换非法时返回错误，不修改进程。
+// 调用者须先转换状态再移动队列，转换失败时进程留在原队列中
+func (s *Scheduler) transition(p *models.PCB, to models.ProcessState, tick int, reason string) error {
+	if !ValidTransition(p.State, to) {
+		return fmt.Errorf("进程 %d 不能从状态 %q 转换到 %q（%s）", p.PID, p.State, to, reason)
+	}
+	p.History = append(p.History, models.StateChange{Tick: tick, From: p.State, To: to, Reason: reason})
+	p.State = to
+	return nil
+}
+
+// ProcessHistory 返回进程的状态转换历史
+func (s *Scheduler) ProcessHistory(pid int) ([]models.StateChange, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	p := s.findProcess(pid)
+	if p == nil {
+		return nil, fmt.Errorf("找不到进程 %d", pid)
+	}
+	return append([]models.StateChange{}, p.History...), nil
+}
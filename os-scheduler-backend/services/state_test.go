@@ -0,0 +1,257 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"testing"
+)
+
+func TestValidTransition(t *testing.T) {
+	states := []models.ProcessState{
+		"", models.New, models.Ready, models.Running, models.Waiting,
+		models.SuspendedReady, models.SuspendedBlocked, models.Finished,
+	}
+	type pair struct{ from, to models.ProcessState }
+	valid := map[pair]bool{
+		{"", models.New}:                                 true,
+		{"", models.Ready}:                               true,
+		{"", models.Waiting}:                             true,
+		{models.New, models.Ready}:                       true,
+		{models.New, models.Waiting}:                     true,
+		{models.Ready, models.Running}:                   true,
+		{models.Ready, models.SuspendedReady}:            true,
+		{models.Running, models.Ready}:                   true,
+		{models.Running, models.Waiting}:                 true,
+		{models.Running, models.SuspendedReady}:          true,
+		{models.Running, models.Finished}:                true,
+		{models.Waiting, models.Ready}:                   true,
+		{models.Waiting, models.SuspendedBlocked}:        true,
+		{models.SuspendedReady, models.Ready}:            true,
+		{models.SuspendedBlocked, models.Waiting}:        true,
+		{models.SuspendedBlocked, models.SuspendedReady}: true,
+	}
+	for _, from := range states {
+		for _, to := range states {
+			if got := ValidTransition(from, to); got != valid[pair{from, to}] {
+				t.Errorf("ValidTransition(%q, %q) = %v, want %v", from, to, got, !got)
+			}
+		}
+	}
+}
+
+func TestIllegalTransitionReturnsError(t *testing.T) {
+	s := newTestSystem(t, nil)
+	p := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 2}))
+	history := len(p.History)
+	if err := s.transition(p, models.Finished, s.Clock, "test"); err == nil {
+		t.Error("transition from ready to finished succeeded")
+	}
+	if p.State != models.Ready || len(p.History) != history {
+		t.Errorf("illegal transition changed the process: state=%s history=%d", p.State, len(p.History))
+	}
+}
+
+func TestIllegalSuspendKeepsProcessQueued(t *testing.T) {
+	s := newTestSystem(t, nil)
+	pid := addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 2})
+	p := s.findProcess(pid)
+	// 状态与所在队列不一致时挂起和恢复都应失败，进程留在原队列中
+	p.State = models.Finished
+	if err := s.SuspendProcess(pid); err == nil {
+		t.Fatal("SuspendProcess accepted a finished process")
+	}
+	if len(s.Queue.Ready) != 1 || len(s.Queue.Suspended) != 0 {
+		t.Fatalf("process moved: ready=%d suspended=%d", len(s.Queue.Ready), len(s.Queue.Suspended))
+	}
+
+	p.State = models.Ready
+	if err := s.SuspendProcess(pid); err != nil {
+		t.Fatalf("SuspendProcess: %v", err)
+	}
+	p.State = models.Finished
+	if err := s.ResumeProcess(pid); err == nil {
+		t.Fatal("ResumeProcess accepted a finished process")
+	}
+	if len(s.Queue.Suspended) != 1 || len(s.Queue.Ready) != 0 {
+		t.Fatalf("process moved: ready=%d suspended=%d", len(s.Queue.Ready), len(s.Queue.Suspended))
+	}
+}
+
+func TestSuspendResumeFromEachQueue(t *testing.T) {
+	cases := []struct {
+		name      string
+		setup     func(t *testing.T, s *Scheduler) int // 返回要挂起的进程
+		suspended models.ProcessState
+		resumed   models.ProcessState
+		queue     func(q *models.ProcessQueue) []*models.PCB // 恢复后所在的队列
+	}{
+		{
+			name: "ready",
+			setup: func(t *testing.T, s *Scheduler) int {
+				return addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 3})
+			},
+			suspended: models.SuspendedReady,
+			resumed:   models.Ready,
+			queue:     func(q *models.ProcessQueue) []*models.PCB { return q.Ready },
+		},
+		{
+			name: "running",
+			setup: func(t *testing.T, s *Scheduler) int {
+				pid := addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 3})
+				s.Schedule()
+				return pid
+			},
+			suspended: models.SuspendedReady,
+			resumed:   models.Ready,
+			queue:     func(q *models.ProcessQueue) []*models.PCB { return q.Ready },
+		},
+		{
+			name: "waiting",
+			setup: func(t *testing.T, s *Scheduler) int {
+				pred := addTestProcess(t, s, &models.PCB{Name: "pred", RequiredTime: 5})
+				return addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 3, Predecessors: []int{pred}})
+			},
+			suspended: models.SuspendedBlocked,
+			resumed:   models.Waiting,
+			queue:     func(q *models.ProcessQueue) []*models.PCB { return q.Waiting },
+		},
+		{
+			name: "backup",
+			setup: func(t *testing.T, s *Scheduler) int {
+				addTestProcess(t, s, &models.PCB{Name: "first", RequiredTime: 5})
+				return addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 3})
+			},
+			suspended: models.SuspendedReady,
+			resumed:   models.Ready,
+			queue:     func(q *models.ProcessQueue) []*models.PCB { return q.Backup },
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestSystem(t, func(cfg *models.SystemConfig) {
+				cfg.ProcessorCount = 1
+				if tc.name == "backup" {
+					cfg.MaxProcesses = 1
+				}
+			})
+			pid := tc.setup(t, s)
+			p := s.findProcess(pid)
+
+			if err := s.SuspendProcess(pid); err != nil {
+				t.Fatalf("SuspendProcess: %v", err)
+			}
+			if p.State != tc.suspended || p.ProcessorID != -1 || !contains(s.Queue.Suspended, pid) {
+				t.Fatalf("after suspend: state=%s cpu=%d", p.State, p.ProcessorID)
+			}
+			if err := s.SuspendProcess(pid); err == nil {
+				t.Error("suspending a suspended process succeeded")
+			}
+
+			if err := s.ResumeProcess(pid); err != nil {
+				t.Fatalf("ResumeProcess: %v", err)
+			}
+			if p.State != tc.resumed || !contains(tc.queue(s.Queue), pid) || contains(s.Queue.Suspended, pid) {
+				t.Fatalf("after resume: state=%s", p.State)
+			}
+			if err := s.ResumeProcess(pid); err == nil {
+				t.Error("resuming a process that is not suspended succeeded")
+			}
+
+			last := p.History[len(p.History)-1]
+			if last.From != tc.suspended || last.To != tc.resumed {
+				t.Errorf("last history entry = %+v", last)
+			}
+		})
+	}
+}
+
+// contains 判断队列中是否有指定PID的进程
+func contains(queue []*models.PCB, pid int) bool {
+	for _, p := range queue {
+		if p.PID == pid {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSuspendedBlockedBecomesReadyWhenPredecessorFinishes(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.ProcessorCount = 1 })
+	pred := addTestProcess(t, s, &models.PCB{Name: "pred", RequiredTime: 2})
+	pid := addTestProcess(t, s, &models.PCB{Name: "succ", RequiredTime: 2, Predecessors: []int{pred}})
+	p := s.findProcess(pid)
+	if err := s.SuspendProcess(pid); err != nil {
+		t.Fatalf("SuspendProcess: %v", err)
+	}
+
+	for s.findProcess(pred).State != models.Finished {
+		s.Schedule()
+	}
+	if p.State != models.SuspendedReady || !contains(s.Queue.Suspended, pid) {
+		t.Fatalf("state = %s after the predecessor finished, want suspended_ready", p.State)
+	}
+	// 挂起就绪的进程不会被调度
+	s.Schedule()
+	if p.CPUTime != 0 {
+		t.Fatalf("suspended process ran for %d ticks", p.CPUTime)
+	}
+
+	if err := s.ResumeProcess(pid); err != nil {
+		t.Fatalf("ResumeProcess: %v", err)
+	}
+	if p.State != models.Ready || !contains(s.Queue.Ready, pid) {
+		t.Fatalf("state = %s after resume, want ready", p.State)
+	}
+	runUntilDone(t, s, 20)
+}
+
+func TestSuspendedLockWaiterBecomesReadyOnRelease(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) { cfg.ProcessorCount = 2 })
+	section := []models.CriticalSection{{Lock: "l", Start: 0, Length: 3}}
+	holder := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "holder", RequiredTime: 3, Priority: 9, CriticalSections: section}))
+	s.Schedule()
+	waiter := s.findProcess(addTestProcess(t, s, &models.PCB{Name: "waiter", RequiredTime: 3, CriticalSections: section}))
+	for waiter.BlockedOn == "" {
+		s.Schedule()
+	}
+	if err := s.SuspendProcess(waiter.PID); err != nil {
+		t.Fatalf("SuspendProcess: %v", err)
+	}
+	if waiter.State != models.SuspendedBlocked {
+		t.Fatalf("state = %s, want suspended_blocked", waiter.State)
+	}
+
+	for holder.State != models.Finished {
+		s.Schedule()
+	}
+	// 挂起的进程不获得锁，转为挂起就绪，恢复后重新申请
+	if waiter.State != models.SuspendedReady || waiter.BlockedOn != "" || waiter.HeldLock != "" {
+		t.Fatalf("after release: state=%s blocked=%q held=%q", waiter.State, waiter.BlockedOn, waiter.HeldLock)
+	}
+	if err := s.ResumeProcess(waiter.PID); err != nil {
+		t.Fatalf("ResumeProcess: %v", err)
+	}
+	runUntilDone(t, s, 30)
+	if waiter.LockWait == 0 {
+		t.Error("lock wait not counted for the suspended waiter")
+	}
+}
+
+func TestRestoreMigratesLegacySuspendedState(t *testing.T) {
+	s := newTestSystem(t, nil)
+	pid := addTestProcess(t, s, &models.PCB{Name: "p", RequiredTime: 3})
+	if err := s.SuspendProcess(pid); err != nil {
+		t.Fatalf("SuspendProcess: %v", err)
+	}
+	snap := s.Snapshot()
+	snap.Queue.Suspended[0].State = "suspended"
+
+	if err := s.Restore(snap); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if p := s.findProcess(pid); p.State != models.SuspendedReady {
+		t.Fatalf("state = %s, want suspended_ready", p.State)
+	}
+	if err := s.ResumeProcess(pid); err != nil {
+		t.Fatalf("ResumeProcess: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"os-scheduler-backend/models"
+	"sort"
+)
+
+// Stats 统计各进程的周转时间、等待时间、响应时间以及系统吞吐量和处理机利用率
+func (s *Scheduler) Stats() *models.Stats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.stats()
+}
+
+func (s *Scheduler) stats() *models.Stats {
+	processes := s.allProcesses()
+	stats := &models.Stats{
+		Clock:      s.Clock,
+		Processes:  len(processes),
+		PerProcess: make([]models.ProcessStats, 0, len(processes)),
+	}
+
+	started := 0
+	for _, p := range processes {
+		ps := models.ProcessStats{
+			PID:        p.PID,
+			Name:       p.Name,
+			Arrival:    p.ArrivalTime,
+			Start:      p.StartTime,
+			Burst:      p.TotalRequiredTime,
+			CPUTime:    p.CPUTime,
+			Finished:   p.State == models.Finished,
+			Migrations: p.Migrations,
+			MaxWait:    p.MaxWait,
+			LockWait:   p.LockWait,
+		}
+		stats.Migrations += p.Migrations
+		if p.MaxWait > stats.MaxWait {
+			stats.MaxWait = p.MaxWait
+		}
+		if p.StartTime >= 0 {
+			ps.Response = p.StartTime - p.ArrivalTime
+			stats.AvgResponse += float64(ps.Response)
+			started++
+		}
+		if ps.Finished {
+			ps.Finish = p.FinishTime
+			ps.Turnaround = p.FinishTime - p.ArrivalTime
+			ps.Waiting = ps.Turnaround - p.CPUTime
+			stats.AvgTurnaround += float64(ps.Turnaround)
+			stats.AvgWaiting += float64(ps.Waiting)
+			stats.Finished++
+		}
+		stats.PerProcess = append(stats.PerProcess, ps)
+	}
+	sort.Slice(stats.PerProcess, func(i, j int) bool {
+		return stats.PerProcess[i].PID < stats.PerProcess[j].PID
+	})
+
+	stats.Groups, stats.Users = s.ownerStats(processes)
+	stats.Gangs = s.gangStats()
+	stats.Tasks = s.taskStats()
+	for _, ts := range stats.Tasks {
+		stats.DeadlineMisses += ts.Missed
+	}
+
+	if stats.Finished > 0 {
+		stats.AvgTurnaround /= float64(stats.Finished)
+		stats.AvgWaiting /= float64(stats.Finished)
+	}
+	if started > 0 {
+		stats.AvgResponse /= float64(started)
+	}
+	if s.Clock > 0 {
+		stats.Throughput = float64(stats.Finished) / float64(s.Clock)
+	}
+
+	switches, overhead, byPID := switchStats(s.Timeline, s.ProcessorCount)
+	for i := range stats.PerProcess {
+		stats.PerProcess[i].ContextSwitches = byPID[stats.PerProcess[i].PID]
+	}
+	stats.PerProcessor = make([]models.ProcessorStats, s.ProcessorCount)
+	for i, spec := range s.Processors {
+		stats.PerProcessor[i] = models.ProcessorStats{
+			ID:            i,
+			Speed:         spec.Speed,
+			Type:          spec.Type,
+			Switches:      switches[i],
+			OverheadTicks: overhead[i],
+		}
+		stats.ContextSwitches += switches[i]
+		stats.OverheadTicks += overhead[i]
+	}
+	busy, total := 0, 0
+	for _, slot := range s.Timeline {
+		for cpu, pid := range slot.Processors {
+			if pid >= 0 {
+				busy++
+			}
+			total++
+			if cpu < len(stats.PerProcessor) {
+				stats.PerProcessor[cpu].Ticks++
+				if pid >= 0 {
+					stats.PerProcessor[cpu].BusyTicks++
+				}
+			}
+		}
+	}
+	if total > 0 {
+		stats.CPUUtilization = float64(busy) / float64(total)
+		stats.EffectiveUtilization = float64(busy-stats.OverheadTicks) / float64(total)
+	}
+	for i := range stats.PerProcessor {
+		if ps := &stats.PerProcessor[i]; ps.Ticks > 0 {
+			ps.Utilization = float64(ps.BusyTicks) / float64(ps.Ticks)
+			ps.EffectiveUtilization = float64(ps.BusyTicks-ps.OverheadTicks) / float64(ps.Ticks)
+		}
+	}
+	return stats
+}
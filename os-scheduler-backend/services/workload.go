@@ -0,0 +1,386 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os-scheduler-backend/models"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 负载文件格式
+const (
+	WorkloadJSON = "json"
+	WorkloadCSV  = "csv"
+)
+
+// workloadCSVHeader CSV格式负载的列，前驱之间用分号分隔
+var workloadCSVHeader = []string{"id", "name", "arrival", "burst", "priority", "memory", "predecessors", "affinity", "gang", "tickets", "sections", "user", "group"}
+
+// LoadWorkload 从文件读取负载，扩展名为.csv时按CSV格式解析，否则按JSON格式解析。
+// CSV格式不包含负载名称，使用文件名作为负载名称
+func LoadWorkload(path string) (*models.Workload, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	format := WorkloadJSON
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		format = WorkloadCSV
+	}
+	workload, err := ParseWorkload(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("解析负载文件失败: %w", err)
+	}
+	if workload.Name == "" {
+		workload.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return workload, nil
+}
+
+// ParseWorkload 按指定格式解析负载
+func ParseWorkload(r io.Reader, format string) (*models.Workload, error) {
+	switch format {
+	case WorkloadJSON:
+		var workload models.Workload
+		if err := json.NewDecoder(r).Decode(&workload); err != nil {
+			return nil, err
+		}
+		return &workload, nil
+	case WorkloadCSV:
+		return parseWorkloadCSV(r)
+	default:
+		return nil, fmt.Errorf("不支持的负载格式 %s", format)
+	}
+}
+
+// parseWorkloadCSV 解析CSV格式的负载。第一行为表头，列的顺序任意，
+// name和burst列必须存在，其余列可以省略
+func parseWorkloadCSV(r io.Reader) (*models.Workload, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("缺少表头")
+	} else if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"name", "burst"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("缺少 %s 列", required)
+		}
+	}
+
+	workload := &models.Workload{Processes: make([]models.WorkloadProcess, 0)}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		number := func(name string) (int, error) {
+			value := field(name)
+			if value == "" {
+				return 0, nil
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return 0, fmt.Errorf("第 %d 行的 %s 不是整数: %s", line, name, value)
+			}
+			return n, nil
+		}
+
+		process := models.WorkloadProcess{
+			ID:           field("id"),
+			Name:         field("name"),
+			Gang:         field("gang"),
+			User:         field("user"),
+			Group:        field("group"),
+			Predecessors: make([]string, 0),
+		}
+		if process.Arrival, err = number("arrival"); err != nil {
+			return nil, err
+		}
+		if process.Burst, err = number("burst"); err != nil {
+			return nil, err
+		}
+		if process.Priority, err = number("priority"); err != nil {
+			return nil, err
+		}
+		if process.Memory, err = number("memory"); err != nil {
+			return nil, err
+		}
+		if process.Tickets, err = number("tickets"); err != nil {
+			return nil, err
+		}
+		for _, pred := range strings.Split(field("predecessors"), ";") {
+			if pred = strings.TrimSpace(pred); pred != "" {
+				process.Predecessors = append(process.Predecessors, pred)
+			}
+		}
+		if process.CriticalSections, err = parseSections(field("sections")); err != nil {
+			return nil, fmt.Errorf("第 %d 行的 sections 无效: %w", line, err)
+		}
+		// 亲和性掩码可以写成十进制或0x开头的十六进制
+		if value := field("affinity"); value != "" {
+			if process.Affinity, err = strconv.ParseUint(value, 0, 64); err != nil {
+				return nil, fmt.Errorf("第 %d 行的 affinity 不是有效的掩码: %s", line, value)
+			}
+		}
+		workload.Processes = append(workload.Processes, process)
+	}
+	return workload, nil
+}
+
+// WriteWorkload 按指定格式输出负载
+func WriteWorkload(w io.Writer, workload *models.Workload, format string) error {
+	switch format {
+	case WorkloadJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(workload)
+	case WorkloadCSV:
+		writer := csv.NewWriter(w)
+		writer.Write(workloadCSVHeader)
+		for _, p := range workload.Processes {
+			writer.Write([]string{
+				p.ID,
+				p.Name,
+				strconv.Itoa(p.Arrival),
+				strconv.Itoa(p.Burst),
+				strconv.Itoa(p.Priority),
+				strconv.Itoa(p.Memory),
+				strings.Join(p.Predecessors, ";"),
+				affinityText(p.Affinity),
+				p.Gang,
+				ticketsText(p.Tickets),
+				sectionsText(p.CriticalSections),
+				p.User,
+				p.Group,
+			})
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("不支持的负载格式 %s", format)
+	}
+}
+
+// affinityText 将亲和性掩码写成十六进制，不限制时为空
+func affinityText(mask uint64) string {
+	if mask == 0 {
+		return ""
+	}
+	return fmt.Sprintf("0x%x", mask)
+}
+
+// ticketsText 写出彩票数，默认值为空
+func ticketsText(count int) string {
+	if count == 0 {
+		return ""
+	}
+	return strconv.Itoa(count)
+}
+
+// parseSections 解析分号分隔的临界区列表，每项为“锁名:开始位置:长度”
+func parseSections(text string) ([]models.CriticalSection, error) {
+	var sections []models.CriticalSection
+	for _, item := range strings.Split(text, ";") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("临界区应写成 锁名:开始位置:长度: %s", item)
+		}
+		start, err1 := strconv.Atoi(strings.TrimSpace(parts[1]))
+		length, err2 := strconv.Atoi(strings.TrimSpace(parts[2]))
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("临界区的开始位置和长度必须是整数: %s", item)
+		}
+		sections = append(sections, models.CriticalSection{Lock: strings.TrimSpace(parts[0]), Start: start, Length: length})
+	}
+	return sections, nil
+}
+
+// sectionsText 将临界区写成“锁名:开始位置:长度”，多个临界区用分号分隔
+func sectionsText(sections []models.CriticalSection) string {
+	items := make([]string, 0, len(sections))
+	for _, cs := range sections {
+		items = append(items, fmt.Sprintf("%s:%d:%d", cs.Lock, cs.Start, cs.Length))
+	}
+	return strings.Join(items, ";")
+}
+
+// EncodeWorkload 按指定格式将负载编码为字节
+func EncodeWorkload(workload *models.Workload, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteWorkload(&buf, workload, format); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportWorkload 将当前各队列中的进程导出为负载，导出的负载重新导入后得到相同的进程集合。
+// 到达时间以最早到达的进程为0，优先数为提交时的优先数；进程名互不相同时用进程名作为负载内ID，否则使用“p<PID>”。
+// includeFinished为false时不导出已完成的进程，指向它们的前驱关系也一并省略
+func (s *Scheduler) ExportWorkload(name string, includeFinished bool) *models.Workload {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	processes := make([]*models.PCB, 0)
+	for _, p := range s.allProcesses() {
+		if includeFinished || p.State != models.Finished {
+			processes = append(processes, p)
+		}
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].PID < processes[j].PID
+	})
+
+	useNames := true
+	names := make(map[string]bool, len(processes))
+	for _, p := range processes {
+		if p.Name == "" || names[p.Name] {
+			useNames = false
+			break
+		}
+		names[p.Name] = true
+	}
+	ids := make(map[int]string, len(processes))
+	base := 0
+	for i, p := range processes {
+		ids[p.PID] = fmt.Sprintf("p%d", p.PID)
+		if useNames {
+			ids[p.PID] = p.Name
+		}
+		if i == 0 || p.ArrivalTime < base {
+			base = p.ArrivalTime
+		}
+	}
+
+	workload := &models.Workload{Name: name, Processes: make([]models.WorkloadProcess, 0, len(processes))}
+	for _, p := range processes {
+		process := models.WorkloadProcess{
+			ID:               ids[p.PID],
+			Name:             p.Name,
+			Arrival:          p.ArrivalTime - base,
+			Burst:            p.TotalRequiredTime,
+			Priority:         p.InitialPriority,
+			Memory:           p.MemorySize,
+			Affinity:         p.AffinityMask,
+			Gang:             p.Gang,
+			Tickets:          p.Tickets,
+			User:             p.User,
+			Group:            p.Group,
+			Predecessors:     make([]string, 0, len(p.Predecessors)),
+			CriticalSections: append([]models.CriticalSection(nil), p.CriticalSections...),
+		}
+		for _, predPID := range p.Predecessors {
+			if id, ok := ids[predPID]; ok {
+				process.Predecessors = append(process.Predecessors, id)
+			}
+		}
+		workload.Processes = append(workload.Processes, process)
+	}
+	return workload
+}
+
+// workloadID 返回负载进程的负载内ID，未指定时使用进程名
+func workloadID(p models.WorkloadProcess) string {
+	if p.ID != "" {
+		return p.ID
+	}
+	return p.Name
+}
+
+// SubmitWorkload 提交负载。所有进程立即获得PID并进入新建队列，到达时刻以当前时钟为基准；
+// 进程到达后才分配内存进入系统，内存不足时继续留在新建队列中等待。
+// 返回负载内ID到PID的映射
+func (s *Scheduler) SubmitWorkload(workload *models.Workload) (map[string]int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	batch := make([]models.BatchProcess, 0, len(workload.Processes))
+	for _, p := range workload.Processes {
+		id := workloadID(p)
+		if p.Arrival < 0 {
+			return nil, fmt.Errorf("进程 %s 的到达时间不能为负数", id)
+		}
+		batch = append(batch, models.BatchProcess{
+			ID:               id,
+			Name:             p.Name,
+			RequiredTime:     p.Burst,
+			Priority:         p.Priority,
+			MemorySize:       p.Memory,
+			AffinityMask:     p.Affinity,
+			Gang:             p.Gang,
+			Tickets:          p.Tickets,
+			User:             p.User,
+			Group:            p.Group,
+			Predecessors:     p.Predecessors,
+			CriticalSections: p.CriticalSections,
+		})
+	}
+
+	mapping, processes, err := s.prepareBatch(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	s.nextPID += len(processes)
+	for i, process := range processes {
+		s.transition(process, models.New, s.Clock, "提交负载")
+		process.ArrivalTime = s.Clock + workload.Processes[i].Arrival
+		s.Queue.New = append(s.Queue.New, process)
+	}
+	s.admitArrivals()
+
+	s.eventLog.Record(OpSubmitWorkload, submitWorkloadArgs{Workload: workload, Mapping: mapping})
+	return mapping, nil
+}
+
+// admitArrivals 为已到达的新进程分配内存并放入相应队列，分配失败的进程留待下次调度重试
+func (s *Scheduler) admitArrivals() {
+	remaining := make([]*models.PCB, 0, len(s.Queue.New))
+	admitted := make([]*models.PCB, 0)
+	for _, p := range s.Queue.New {
+		if p.ArrivalTime > s.Clock {
+			remaining = append(remaining, p)
+			continue
+		}
+		start, err := s.memoryManager.allocate(p.MemorySize)
+		if err != nil {
+			remaining = append(remaining, p)
+			continue
+		}
+		p.MemoryStart = start
+		admitted = append(admitted, p)
+	}
+
+	s.insertProcesses(admitted)
+	s.Queue.New = remaining
+}
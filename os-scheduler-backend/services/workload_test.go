@@ -0,0 +1,92 @@
+package services
+
+import (
+	"bytes"
+	"os-scheduler-backend/models"
+	"reflect"
+	"testing"
+)
+
+// workloadFixture 返回有前驱关系的小负载
+func workloadFixture() *models.Workload {
+	return &models.Workload{
+		Name: "fixture",
+		Processes: []models.WorkloadProcess{
+			{ID: "a", Name: "a", Arrival: 0, Burst: 4, Priority: 5, Memory: 64, Predecessors: []string{}},
+			{ID: "b", Name: "b", Arrival: 1, Burst: 2, Priority: 3, Memory: 32, Predecessors: []string{}},
+			{ID: "c", Name: "c", Arrival: 2, Burst: 3, Priority: 8, Memory: 16, Predecessors: []string{"a", "b"}},
+		},
+	}
+}
+
+func TestWorkloadFormatsRoundTrip(t *testing.T) {
+	for _, format := range []string{WorkloadJSON, WorkloadCSV} {
+		t.Run(format, func(t *testing.T) {
+			original := workloadFixture()
+			data, err := EncodeWorkload(original, format)
+			if err != nil {
+				t.Fatalf("EncodeWorkload: %v", err)
+			}
+			parsed, err := ParseWorkload(bytes.NewReader(data), format)
+			if err != nil {
+				t.Fatalf("ParseWorkload: %v", err)
+			}
+			if !reflect.DeepEqual(parsed.Processes, original.Processes) {
+				t.Errorf("round trip changed the processes\ngot:  %+v\nwant: %+v", parsed.Processes, original.Processes)
+			}
+		})
+	}
+}
+
+func TestParseWorkloadRejectsBadCSV(t *testing.T) {
+	cases := map[string]string{
+		"missing burst": "name,priority\na,1\n",
+		"not a number":  "name,burst\na,x\n",
+	}
+	for name, text := range cases {
+		if _, err := ParseWorkload(bytes.NewReader([]byte(text)), WorkloadCSV); err == nil {
+			t.Errorf("%s: ParseWorkload accepted %q", name, text)
+		}
+	}
+}
+
+func TestExportWorkloadRoundTrip(t *testing.T) {
+	s := newTestSystem(t, func(cfg *models.SystemConfig) {
+		cfg.ProcessorCount = 1
+		cfg.Policy = models.PolicyPriority
+	})
+	if _, err := s.SubmitWorkload(workloadFixture()); err != nil {
+		t.Fatalf("SubmitWorkload: %v", err)
+	}
+	// 动态优先数在运行中降低，导出的仍是提交时的优先数
+	for i := 0; i < 3; i++ {
+		s.Schedule()
+	}
+	exported := s.ExportWorkload("fixture", true)
+	if !reflect.DeepEqual(exported.Processes, workloadFixture().Processes) {
+		t.Fatalf("exported workload differs\ngot:  %+v\nwant: %+v", exported.Processes, workloadFixture().Processes)
+	}
+
+	// 重新导入导出的负载得到相同的进程集合
+	again := newTestSystem(t, nil)
+	if _, err := again.SubmitWorkload(exported); err != nil {
+		t.Fatalf("SubmitWorkload: %v", err)
+	}
+	if got := again.ExportWorkload("fixture", true); !reflect.DeepEqual(got, exported) {
+		t.Errorf("re-imported workload differs\ngot:  %+v\nwant: %+v", got, exported)
+	}
+}
+
+func TestExportWorkloadOmitsFinished(t *testing.T) {
+	s := newTestSystem(t, nil)
+	if _, err := s.SubmitWorkload(workloadFixture()); err != nil {
+		t.Fatalf("SubmitWorkload: %v", err)
+	}
+	for s.findProcess(1).State != models.Finished || s.findProcess(2).State != models.Finished {
+		s.Schedule()
+	}
+	exported := s.ExportWorkload("rest", false)
+	if len(exported.Processes) != 1 || exported.Processes[0].ID != "c" || len(exported.Processes[0].Predecessors) != 0 {
+		t.Errorf("exported = %+v, want only c without predecessors", exported.Processes)
+	}
+}